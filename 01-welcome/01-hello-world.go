@@ -1,8 +1,22 @@
 // https://go.dev/tour/welcome/1
 package welcome
 
-import "fmt"
+import (
+	"fmt"
+	"io"
 
-func RunWelcome01() {
-	fmt.Println("Hello, world!")
+	"first-golang/registry"
+)
+
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "01-01",
+		Title:   "Hello, 世界",
+		TourURL: "https://go.dev/tour/welcome/1",
+		Run:     RunWelcome01,
+	})
+}
+
+func RunWelcome01(w io.Writer) {
+	fmt.Fprintln(w, "Hello, world!")
 }
@@ -3,11 +3,23 @@ package welcome
 
 import (
 	"fmt"
+	"io"
 	"time"
+
+	"first-golang/registry"
 )
 
-func RunWelcome04() {
-	fmt.Println("Welcome to the playground!")
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "01-04",
+		Title:   "The Go Playground",
+		TourURL: "https://go.dev/tour/welcome/4",
+		Run:     RunWelcome04,
+	})
+}
+
+func RunWelcome04(w io.Writer) {
+	fmt.Fprintln(w, "Welcome to the playground!")
 
-	fmt.Println("The time is", time.Now())
+	fmt.Fprintln(w, "The time is", time.Now())
 }
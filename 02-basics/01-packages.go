@@ -3,9 +3,21 @@ package basics
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
+
+	"first-golang/registry"
 )
 
-func RunBasics01() {
-	fmt.Println("My favorite number is", rand.Intn(10))
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-01",
+		Title:   "Packages",
+		TourURL: "https://go.dev/tour/basics/1",
+		Run:     RunBasics01,
+	})
+}
+
+func RunBasics01(w io.Writer) {
+	fmt.Fprintln(w, "My favorite number is", rand.Intn(10))
 }
@@ -3,9 +3,21 @@ package basics
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
-func RunBasics02() {
-	fmt.Printf("Now you have %g problems.\n", math.Sqrt(7))
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-02",
+		Title:   "Imports",
+		TourURL: "https://go.dev/tour/basics/2",
+		Run:     RunBasics02,
+	})
+}
+
+func RunBasics02(w io.Writer) {
+	fmt.Fprintf(w, "Now you have %g problems.\n", math.Sqrt(7))
 }
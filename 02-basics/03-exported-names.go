@@ -3,9 +3,21 @@ package basics
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
-func RunBasics03() {
-	fmt.Println(math.Pi)
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-03",
+		Title:   "Exported names",
+		TourURL: "https://go.dev/tour/basics/3",
+		Run:     RunBasics03,
+	})
+}
+
+func RunBasics03(w io.Writer) {
+	fmt.Fprintln(w, math.Pi)
 }
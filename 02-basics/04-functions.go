@@ -1,12 +1,26 @@
 // https://go.dev/tour/basics/4
 package basics
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+
+	"first-golang/registry"
+)
 
 func addBasics04(x int, y int, z int) int {
 	return x + y + z
 }
 
-func RunBasics04() {
-	fmt.Println(addBasics04(42, 13, 15))
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-04",
+		Title:   "Functions",
+		TourURL: "https://go.dev/tour/basics/4",
+		Run:     RunBasics04,
+	})
+}
+
+func RunBasics04(w io.Writer) {
+	fmt.Fprintln(w, addBasics04(42, 13, 15))
 }
@@ -1,12 +1,26 @@
 // https://go.dev/tour/basics/5
 package basics
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+
+	"first-golang/registry"
+)
 
 func addBasics05(x, y, z int) int {
 	return x + y + z
 }
 
-func RunBasics05() {
-	fmt.Println(addBasics05(42, 13, 50))
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-05",
+		Title:   "Functions continued",
+		TourURL: "https://go.dev/tour/basics/5",
+		Run:     RunBasics05,
+	})
+}
+
+func RunBasics05(w io.Writer) {
+	fmt.Fprintln(w, addBasics05(42, 13, 50))
 }
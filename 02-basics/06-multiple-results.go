@@ -1,13 +1,27 @@
 // https://go.dev/tour/basics/6
 package basics
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+
+	"first-golang/registry"
+)
 
 func swap(x, y, z string) (string, string, string) {
 	return z, x, y
 }
 
-func RunBasics06() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-06",
+		Title:   "Multiple results",
+		TourURL: "https://go.dev/tour/basics/6",
+		Run:     RunBasics06,
+	})
+}
+
+func RunBasics06(w io.Writer) {
 	a, b, c := swap("test", "hello", "world")
-	fmt.Println(a, b, c)
+	fmt.Fprintln(w, a, b, c)
 }
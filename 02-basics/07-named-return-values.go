@@ -1,7 +1,12 @@
 // https://go.dev/tour/basics/7
 package basics
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+
+	"first-golang/registry"
+)
 
 func split(sum int) (x, y int) {
 	x = sum * 4 / 9
@@ -9,6 +14,16 @@ func split(sum int) (x, y int) {
 	return
 }
 
-func RunBasics07() {
-	fmt.Println(split(17))
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-07",
+		Title:   "Named return values",
+		TourURL: "https://go.dev/tour/basics/7",
+		Run:     RunBasics07,
+	})
+}
+
+func RunBasics07(w io.Writer) {
+	x, y := split(17)
+	fmt.Fprintln(w, x, y)
 }
@@ -1,11 +1,25 @@
 // https://go.dev/tour/basics/8
 package basics
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+
+	"first-golang/registry"
+)
 
 var c, python, java bool
 
-func RunBasics08() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-08",
+		Title:   "Variables",
+		TourURL: "https://go.dev/tour/basics/8",
+		Run:     RunBasics08,
+	})
+}
+
+func RunBasics08(w io.Writer) {
 	var i int
-	fmt.Println(i, c, python, java)
+	fmt.Fprintln(w, i, c, python, java)
 }
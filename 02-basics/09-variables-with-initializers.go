@@ -1,11 +1,25 @@
 // https://go.dev/tour/basics/9
 package basics
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+
+	"first-golang/registry"
+)
 
 var i, j int = 1, 2
 
-func RunBasics09() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-09",
+		Title:   "Variables with initializers",
+		TourURL: "https://go.dev/tour/basics/9",
+		Run:     RunBasics09,
+	})
+}
+
+func RunBasics09(w io.Writer) {
 	var c, python, java = true, false, "no!"
-	fmt.Println(i, j, c, python, java)
+	fmt.Fprintln(w, i, j, c, python, java)
 }
@@ -1,12 +1,26 @@
 // https://go.dev/tour/basics/10
 package basics
 
-import "fmt"
+import (
+	"fmt"
+	"io"
 
-func RunBasics10() {
+	"first-golang/registry"
+)
+
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-10",
+		Title:   "Short variable declarations",
+		TourURL: "https://go.dev/tour/basics/10",
+		Run:     RunBasics10,
+	})
+}
+
+func RunBasics10(w io.Writer) {
 	var i, j int = 1, 2
 	k := 3
 	c, python, java := true, false, "no!"
 
-	fmt.Println(i, j, k, c, python, java)
+	fmt.Fprintln(w, i, j, k, c, python, java)
 }
@@ -22,7 +22,10 @@ Go's basic types are
 
 import (
 	"fmt"
+	"io"
 	"math/cmplx"
+
+	"first-golang/registry"
 )
 
 var (
@@ -31,8 +34,17 @@ var (
 	z      complex128 = cmplx.Sqrt(-5 + 12i)
 )
 
-func RunBasics11() {
-	fmt.Printf("Type: %T Value: %v\n", ToBe, ToBe)
-	fmt.Printf("Type: %T Value: %v\n", MaxInt, MaxInt)
-	fmt.Printf("Type: %T Value: %v\n", z, z)
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-11",
+		Title:   "Basic types",
+		TourURL: "https://go.dev/tour/basics/11",
+		Run:     RunBasics11,
+	})
+}
+
+func RunBasics11(w io.Writer) {
+	fmt.Fprintf(w, "Type: %T Value: %v\n", ToBe, ToBe)
+	fmt.Fprintf(w, "Type: %T Value: %v\n", MaxInt, MaxInt)
+	fmt.Fprintf(w, "Type: %T Value: %v\n", z, z)
 }
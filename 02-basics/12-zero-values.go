@@ -3,12 +3,24 @@ package basics
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunBasics12() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-12",
+		Title:   "Zero values",
+		TourURL: "https://go.dev/tour/basics/12",
+		Run:     RunBasics12,
+	})
+}
+
+func RunBasics12(w io.Writer) {
 	var i int
 	var f float64
 	var b bool
 	var s string
-	fmt.Printf("%v %v %v %q\n", i, f, b, s)
+	fmt.Fprintf(w, "%v %v %v %q\n", i, f, b, s)
 }
@@ -3,13 +3,25 @@ package basics
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
-func RunBasics13() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-13",
+		Title:   "Type conversions",
+		TourURL: "https://go.dev/tour/basics/13",
+		Run:     RunBasics13,
+	})
+}
+
+func RunBasics13(w io.Writer) {
 	var x, y int = 3, 4
 	// 如果移除了 float64() 會出錯，也就是不能只寫 math.Sqrt(x*x + y*y)
 	var f float64 = math.Sqrt(float64(x*x + y*y))
 	var z uint = uint(f)
-	fmt.Println(x, y, z)
+	fmt.Fprintln(w, x, y, z)
 }
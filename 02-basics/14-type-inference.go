@@ -3,15 +3,27 @@ package basics
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunBasics14() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-14",
+		Title:   "Type inference",
+		TourURL: "https://go.dev/tour/basics/14",
+		Run:     RunBasics14,
+	})
+}
+
+func RunBasics14(w io.Writer) {
 	v := 42           // change me!
 	i := 42           // int
 	f := 3.142        // float64
 	g := 0.867 + 0.5i // complex128
-	fmt.Printf("v is of type %T\n", v)
-	fmt.Printf("i is of type %T\n", i)
-	fmt.Printf("f is of type %T\n", f)
-	fmt.Printf("g is of type %T\n", g)
+	fmt.Fprintf(w, "v is of type %T\n", v)
+	fmt.Fprintf(w, "i is of type %T\n", i)
+	fmt.Fprintf(w, "f is of type %T\n", f)
+	fmt.Fprintf(w, "g is of type %T\n", g)
 }
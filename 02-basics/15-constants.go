@@ -3,15 +3,27 @@ package basics
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 const Pi = 3.14
 
-func RunBasics15() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-15",
+		Title:   "Constants",
+		TourURL: "https://go.dev/tour/basics/15",
+		Run:     RunBasics15,
+	})
+}
+
+func RunBasics15(w io.Writer) {
 	const World = "世界"
-	fmt.Println("Hello", World)
-	fmt.Println("Happy", Pi, "Day")
+	fmt.Fprintln(w, "Hello", World)
+	fmt.Fprintln(w, "Happy", Pi, "Day")
 
 	const Truth = true
-	fmt.Println("Go rules?", Truth)
+	fmt.Fprintln(w, "Go rules?", Truth)
 }
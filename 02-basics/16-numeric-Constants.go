@@ -3,6 +3,9 @@ package basics
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 const (
@@ -18,10 +21,19 @@ func needFloat(x float64) float64 {
 	return x * 0.1
 }
 
-func RunBasics16() {
-	fmt.Println(needInt(Small))
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "02-16",
+		Title:   "Numeric Constants",
+		TourURL: "https://go.dev/tour/basics/16",
+		Run:     RunBasics16,
+	})
+}
+
+func RunBasics16(w io.Writer) {
+	fmt.Fprintln(w, needInt(Small))
 	// ! 02-basics/16-numeric-Constants.go:23:22: cannot use Big (untyped int constant 1267650600228229401496703205376) as int value in argument to needInt (overflows)
-	// fmt.Println(needInt(Big))
-	fmt.Println(needFloat(Small))
-	fmt.Println(needFloat(Big))
+	// fmt.Fprintln(w, needInt(Big))
+	fmt.Fprintln(w, needFloat(Small))
+	fmt.Fprintln(w, needFloat(Big))
 }
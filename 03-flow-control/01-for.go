@@ -3,13 +3,25 @@ package flowControl
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunFlowControl01() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "03-01",
+		Title:   "For",
+		TourURL: "https://go.dev/tour/flowcontrol/1",
+		Run:     RunFlowControl01,
+	})
+}
+
+func RunFlowControl01(w io.Writer) {
 	sum := 0
 	// 不需要小括號 ()，但大括號 {} 是必須的
 	for i := 0; i < 10; i++ {
 		sum += i
 	}
-	fmt.Println(sum)
+	fmt.Fprintln(w, sum)
 }
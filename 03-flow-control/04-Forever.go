@@ -1,11 +1,25 @@
 // https://go.dev/tour/flowcontrol/4
 package flowControl
 
-import "fmt"
+import (
+	"fmt"
+	"io"
 
-func RunFlowControl04() {
+	"first-golang/registry"
+)
+
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "03-04",
+		Title:   "Forever",
+		TourURL: "https://go.dev/tour/flowcontrol/4",
+		Run:     RunFlowControl04,
+	})
+}
+
+func RunFlowControl04(w io.Writer) {
 	// 無限迴圈
 	for {
-		fmt.Println("Infinite loop")
+		fmt.Fprintln(w, "Infinite loop")
 	}
 }
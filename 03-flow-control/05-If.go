@@ -3,7 +3,10 @@ package flowControl
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 func sqrt(x float64) string {
@@ -14,6 +17,15 @@ func sqrt(x float64) string {
 	return fmt.Sprint(math.Sqrt(x))
 }
 
-func RunFlowControl05() {
-	fmt.Println(sqrt(2), sqrt(-4))
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "03-05",
+		Title:   "If",
+		TourURL: "https://go.dev/tour/flowcontrol/5",
+		Run:     RunFlowControl05,
+	})
+}
+
+func RunFlowControl05(w io.Writer) {
+	fmt.Fprintln(w, sqrt(2), sqrt(-4))
 }
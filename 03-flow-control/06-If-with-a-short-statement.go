@@ -3,7 +3,10 @@ package flowControl
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 func pow(x, n, lim float64) float64 {
@@ -14,8 +17,17 @@ func pow(x, n, lim float64) float64 {
 	return lim
 }
 
-func RunFlowControl06() {
-	fmt.Println(
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "03-06",
+		Title:   "If with a short statement",
+		TourURL: "https://go.dev/tour/flowcontrol/6",
+		Run:     RunFlowControl06,
+	})
+}
+
+func RunFlowControl06(w io.Writer) {
+	fmt.Fprintln(w,
 		pow(3, 2, 10),
 		pow(3, 3, 20),
 	)
@@ -3,22 +3,34 @@ package flowControl
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
-func pow07(x, n, lim float64) float64 {
+func pow07(w io.Writer, x, n, lim float64) float64 {
 	if v := math.Pow(x, n); v < lim {
 		return v
 	} else {
-		fmt.Printf("%g >= %g\n", v, lim)
+		fmt.Fprintf(w, "%g >= %g\n", v, lim)
 	}
 	// can't use v here, though
 	return lim
 }
 
-func RunFlowControl07() {
-	fmt.Println(
-		pow07(3, 2, 10),
-		pow07(3, 3, 20),
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "03-07",
+		Title:   "If and else",
+		TourURL: "https://go.dev/tour/flowcontrol/7",
+		Run:     RunFlowControl07,
+	})
+}
+
+func RunFlowControl07(w io.Writer) {
+	fmt.Fprintln(w,
+		pow07(w, 3, 2, 10),
+		pow07(w, 3, 3, 20),
 	)
 }
@@ -3,6 +3,11 @@ package flowControl
 
 import (
 	"fmt"
+	"io"
+	"math"
+
+	"first-golang/exercise"
+	"first-golang/registry"
 )
 
 func Sqrt(x float64) float64 {
@@ -19,6 +24,25 @@ func Sqrt(x float64) float64 {
 	return z
 }
 
-func RunFlowControl08() {
-	fmt.Println(Sqrt(7))
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "03-08",
+		Title:   "Exercise: Loops and Functions",
+		TourURL: "https://go.dev/tour/flowcontrol/8",
+		Run:     RunFlowControl08,
+		Check:   checkFlowControl08,
+	})
+}
+
+func RunFlowControl08(w io.Writer) {
+	fmt.Fprintln(w, Sqrt(7))
+}
+
+// checkFlowControl08 驗證牛頓法算出的 Sqrt 與 math.Sqrt 的相對誤差不到 1e-9
+// 題目只要求迭代 10 次，所以測資不放太大的數
+func checkFlowControl08(t *exercise.T) {
+	for _, x := range []float64{1, 2, 7, 100, 1000} {
+		got, want := Sqrt(x), math.Sqrt(x)
+		t.True(fmt.Sprintf("Sqrt(%v)", x), math.Abs(got-want) <= 1e-9*want, "got %v, want %v", got, want)
+	}
 }
@@ -3,11 +3,23 @@ package flowControl
 
 import (
 	"fmt"
+	"io"
 	"runtime"
+
+	"first-golang/registry"
 )
 
-func RunFlowControl09() {
-	fmt.Print("Go runs on ")
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "03-09",
+		Title:   "Switch",
+		TourURL: "https://go.dev/tour/flowcontrol/9",
+		Run:     RunFlowControl09,
+	})
+}
+
+func RunFlowControl09(w io.Writer) {
+	fmt.Fprint(w, "Go runs on ")
 	switch os := runtime.GOOS; os {
 	/*
 		case 的值不需要是常數，也不需要是 integer
@@ -19,13 +31,13 @@ func RunFlowControl09() {
 		- interface
 	*/
 	case "darwin":
-		fmt.Println("macOS.")
+		fmt.Fprintln(w, "macOS.")
 		// 內建 break
 	case "linux":
-		fmt.Println("Linux.")
+		fmt.Fprintln(w, "Linux.")
 	default:
 		// freebsd, openbsd,
 		// plan9, windows...
-		fmt.Printf("%s.\n", os)
+		fmt.Fprintf(w, "%s.\n", os)
 	}
 }
@@ -3,21 +3,33 @@ package flowControl
 
 import (
 	"fmt"
+	"io"
 	"time"
+
+	"first-golang/registry"
 )
 
-func RunFlowControl10() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "03-10",
+		Title:   "Switch evaluation order",
+		TourURL: "https://go.dev/tour/flowcontrol/10",
+		Run:     RunFlowControl10,
+	})
+}
+
+func RunFlowControl10(w io.Writer) {
 	// 彩蛋：2009-11-10 23:00:00 UTC 是 Go 誕生之日
-	fmt.Println("When's Saturday?")
+	fmt.Fprintln(w, "When's Saturday?")
 	today := time.Now().Weekday()
 	switch time.Saturday {
 	case today + 0:
-		fmt.Println("Today.")
+		fmt.Fprintln(w, "Today.")
 	case today + 1:
-		fmt.Println("Tomorrow.")
+		fmt.Fprintln(w, "Tomorrow.")
 	case today + 2:
-		fmt.Println("In two days.")
+		fmt.Fprintln(w, "In two days.")
 	default:
-		fmt.Println("Too far away.")
+		fmt.Fprintln(w, "Too far away.")
 	}
 }
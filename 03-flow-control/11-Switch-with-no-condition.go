@@ -3,17 +3,29 @@ package flowControl
 
 import (
 	"fmt"
+	"io"
 	"time"
+
+	"first-golang/registry"
 )
 
-func RunFlowControl11() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "03-11",
+		Title:   "Switch with no condition",
+		TourURL: "https://go.dev/tour/flowcontrol/11",
+		Run:     RunFlowControl11,
+	})
+}
+
+func RunFlowControl11(w io.Writer) {
 	t := time.Now()
 	switch {
 	case t.Hour() < 12:
-		fmt.Println("Good morning!")
+		fmt.Fprintln(w, "Good morning!")
 	case t.Hour() < 17:
-		fmt.Println("Good afternoon.")
+		fmt.Fprintln(w, "Good afternoon.")
 	default:
-		fmt.Println("Good evening.")
+		fmt.Fprintln(w, "Good evening.")
 	}
 }
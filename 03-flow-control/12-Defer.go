@@ -3,8 +3,20 @@ package flowControl
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "03-12",
+		Title:   "Defer",
+		TourURL: "https://go.dev/tour/flowcontrol/12",
+		Run:     RunFlowControl12,
+	})
+}
+
 /*
 defer 常見用途
 - 關閉檔案
@@ -12,22 +24,22 @@ defer 常見用途
 - 記錄執行時間
 - panic 時仍能收尾
 */
-func RunFlowControl12() {
+func RunFlowControl12(w io.Writer) {
 	// defer 語句會將函數的執行順序延後到外層函數返回之前
-	defer fmt.Println("world")
+	defer fmt.Fprintln(w, "world")
 
-	fmt.Println("hello")
+	fmt.Fprintln(w, "hello")
 	// 輸出: hello world
 	// --------------------------------
 	// defer 會「捕捉」當下的參數值
 	x := 10
-	defer fmt.Println(x)
+	defer fmt.Fprintln(w, x)
 	x = 20
 	// 輸出: 10
 	// --------------------------------
 	// 如果想捕捉變動後的值，需要用 closure：
 	y := 10
-	defer func() { fmt.Println(y) }()
+	defer func() { fmt.Fprintln(w, y) }()
 	y = 20
 	// 輸出: 20
 }
@@ -3,17 +3,29 @@ package flowControl
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunFlowControl13() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "03-13",
+		Title:   "Stacking defers",
+		TourURL: "https://go.dev/tour/flowcontrol/13",
+		Run:     RunFlowControl13,
+	})
+}
+
+func RunFlowControl13(w io.Writer) {
 	// defer 會按照後進先出 (LIFO) 的順序執行
-	fmt.Println("counting")
+	fmt.Fprintln(w, "counting")
 
 	for i := 0; i < 10; i++ {
-		defer fmt.Println(i)
+		defer fmt.Fprintln(w, i)
 	}
 
-	fmt.Println("done")
+	fmt.Fprintln(w, "done")
 	/*
 		輸出：
 		counting
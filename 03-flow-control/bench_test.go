@@ -0,0 +1,14 @@
+package flowControl
+
+import "testing"
+
+// BenchmarkFlowControl08 測量 03-08 以牛頓法計算 Sqrt 的速度
+func BenchmarkFlowControl08(b *testing.B) {
+	b.Run("Sqrt", func(b *testing.B) {
+		for b.Loop() {
+			for _, x := range []float64{2, 7, 100, 1000} {
+				Sqrt(x)
+			}
+		}
+	})
+}
@@ -3,17 +3,29 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes01() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-01",
+		Title:   "Pointers",
+		TourURL: "https://go.dev/tour/moretypes/1",
+		Run:     RunMoreTypes01,
+	})
+}
+
+func RunMoreTypes01(w io.Writer) {
 	i, j := 42, 2701
 
-	p := &i         // point to i
-	fmt.Println(*p) // read i through the pointer
-	*p = 21         // set i through the pointer
-	fmt.Println(i)  // see the new value of i
+	p := &i             // point to i
+	fmt.Fprintln(w, *p) // read i through the pointer
+	*p = 21             // set i through the pointer
+	fmt.Fprintln(w, i)  // see the new value of i
 
-	p = &j         // point to j
-	*p = *p / 37   // divide j through the pointer
-	fmt.Println(j) // see the new value of j
+	p = &j             // point to j
+	*p = *p / 37       // divide j through the pointer
+	fmt.Fprintln(w, j) // see the new value of j
 }
@@ -3,6 +3,9 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 type Vertex struct {
@@ -10,6 +13,15 @@ type Vertex struct {
 	Y int
 }
 
-func RunMoreTypes02() {
-	fmt.Println(Vertex{1, 2})
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-02",
+		Title:   "Structs",
+		TourURL: "https://go.dev/tour/moretypes/2",
+		Run:     RunMoreTypes02,
+	})
+}
+
+func RunMoreTypes02(w io.Writer) {
+	fmt.Fprintln(w, Vertex{1, 2})
 }
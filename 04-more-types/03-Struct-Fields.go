@@ -3,6 +3,9 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 type Vertex03 struct {
@@ -10,10 +13,19 @@ type Vertex03 struct {
 	Y int
 }
 
-func RunMoreTypes03() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-03",
+		Title:   "Struct fields",
+		TourURL: "https://go.dev/tour/moretypes/3",
+		Run:     RunMoreTypes03,
+	})
+}
+
+func RunMoreTypes03(w io.Writer) {
 	v := Vertex03{1, 2}
 	// 寫入新的值到 X
 	v.X = 4
 	// 取得新的 X 值
-	fmt.Println(v.X) // 4
+	fmt.Fprintln(w, v.X) // 4
 }
@@ -3,6 +3,9 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 type Vertex04 struct {
@@ -10,10 +13,19 @@ type Vertex04 struct {
 	Y int
 }
 
-func RunMoreTypes04() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-04",
+		Title:   "Pointers to structs",
+		TourURL: "https://go.dev/tour/moretypes/4",
+		Run:     RunMoreTypes04,
+	})
+}
+
+func RunMoreTypes04(w io.Writer) {
 	v := Vertex04{1, 2}
 	p := &v
 	// 理論上應寫 *p.X，但可省略成 p.X
 	p.X = 1e9
-	fmt.Println(v) // {1000000000 2}
+	fmt.Fprintln(w, v) // {1000000000 2}
 }
@@ -3,6 +3,9 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 type Vertex05 struct {
@@ -16,6 +19,15 @@ var (
 	p  = &Vertex05{1, 2} // has type *Vertex
 )
 
-func RunMoreTypes05() {
-	fmt.Println(v1, p, v2, v3) // {1 2} &{1 2} {1 0} {0 0}
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-05",
+		Title:   "Struct literals",
+		TourURL: "https://go.dev/tour/moretypes/5",
+		Run:     RunMoreTypes05,
+	})
+}
+
+func RunMoreTypes05(w io.Writer) {
+	fmt.Fprintln(w, v1, p, v2, v3) // {1 2} &{1 2} {1 0} {0 0}
 }
@@ -3,15 +3,27 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes06() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-06",
+		Title:   "Arrays",
+		TourURL: "https://go.dev/tour/moretypes/6",
+		Run:     RunMoreTypes06,
+	})
+}
+
+func RunMoreTypes06(w io.Writer) {
 	var a [2]string
 	a[0] = "Hello"
 	a[1] = "World"
-	fmt.Println(a[0], a[1]) // Hello World
-	fmt.Println(a)          // [Hello World]
+	fmt.Fprintln(w, a[0], a[1]) // Hello World
+	fmt.Fprintln(w, a)          // [Hello World]
 
 	primes := [6]int{2, 3, 5, 7, 11, 13}
-	fmt.Println(primes) // [2 3 5 7 11 13]
+	fmt.Fprintln(w, primes) // [2 3 5 7 11 13]
 }
@@ -3,11 +3,23 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes07() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-07",
+		Title:   "Slices",
+		TourURL: "https://go.dev/tour/moretypes/7",
+		Run:     RunMoreTypes07,
+	})
+}
+
+func RunMoreTypes07(w io.Writer) {
 	primes := [6]int{2, 3, 5, 7, 11, 13}
 
 	var s []int = primes[1:4]
-	fmt.Println(s) // [3 5 7]
+	fmt.Fprintln(w, s) // [3 5 7]
 }
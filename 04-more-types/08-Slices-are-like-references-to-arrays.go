@@ -3,23 +3,35 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes08() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-08",
+		Title:   "Slices are like references to arrays",
+		TourURL: "https://go.dev/tour/moretypes/8",
+		Run:     RunMoreTypes08,
+	})
+}
+
+func RunMoreTypes08(w io.Writer) {
 	names := [4]string{
 		"John",
 		"Paul",
 		"George",
 		"Ringo",
 	}
-	fmt.Println(names) // [John Paul George Ringo]
+	fmt.Fprintln(w, names) // [John Paul George Ringo]
 
 	a := names[0:2]
 	b := names[1:3]
-	fmt.Println(a, b) // [John Paul] [Paul George]
+	fmt.Fprintln(w, a, b) // [John Paul] [Paul George]
 
 	b[0] = "XXX"
 	// slice 共享相同的底層 array，所以修改 b[0] 會影響 a[1] 和 names[1]
-	fmt.Println(a, b)  // [John XXX] [XXX George]
-	fmt.Println(names) // [John XXX George Ringo]
+	fmt.Fprintln(w, a, b)  // [John XXX] [XXX George]
+	fmt.Fprintln(w, names) // [John XXX George Ringo]
 }
@@ -3,15 +3,27 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes09() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-09",
+		Title:   "Slice literals",
+		TourURL: "https://go.dev/tour/moretypes/9",
+		Run:     RunMoreTypes09,
+	})
+}
+
+func RunMoreTypes09(w io.Writer) {
 	// slice literal：不用指定長度即建立 array，會根據初始化元素的數量來決定長度
 	q := []int{2, 3, 5, 7, 11, 13}
-	fmt.Println(q) // [2 3 5 7 11 13]
+	fmt.Fprintln(w, q) // [2 3 5 7 11 13]
 
 	r := []bool{true, false, true, true, false, true}
-	fmt.Println(r) // [true false true true false true]
+	fmt.Fprintln(w, r) // [true false true true false true]
 
 	s := []struct {
 		i int
@@ -24,5 +36,5 @@ func RunMoreTypes09() {
 		{11, false},
 		{13, true},
 	}
-	fmt.Println(s) // [{2 true} {3 false} {5 true} {7 true} {11 false} {13 true}]
+	fmt.Fprintln(w, s) // [{2 true} {3 false} {5 true} {7 true} {11 false} {13 true}]
 }
@@ -3,17 +3,29 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes10() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-10",
+		Title:   "Slice defaults",
+		TourURL: "https://go.dev/tour/moretypes/10",
+		Run:     RunMoreTypes10,
+	})
+}
+
+func RunMoreTypes10(w io.Writer) {
 	s := []int{2, 3, 5, 7, 11, 13}
 
 	s = s[1:4]
-	fmt.Println(s) // [3 5 7]
+	fmt.Fprintln(w, s) // [3 5 7]
 
 	s = s[:2]
-	fmt.Println(s) // [3 5]
+	fmt.Fprintln(w, s) // [3 5]
 
 	s = s[1:]
-	fmt.Println(s) // [5]
+	fmt.Fprintln(w, s) // [5]
 }
@@ -3,29 +3,41 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes11() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-11",
+		Title:   "Slice length and capacity",
+		TourURL: "https://go.dev/tour/moretypes/11",
+		Run:     RunMoreTypes11,
+	})
+}
+
+func RunMoreTypes11(w io.Writer) {
 	/*
 		len：目前 slice 的長度
 		cap：目前 slice 的容量，從 slice 的起始位置到底層 array 的末尾位置
 	*/
 	s := []int{2, 3, 5, 7, 11, 13}
-	printSlice(s) // len=6 cap=6 [2 3 5 7 11 13]
+	printSlice(w, s) // len=6 cap=6 [2 3 5 7 11 13]
 
 	// Slice the slice to give it zero length.
 	s = s[:0]
-	printSlice(s) // len=0 cap=6 []
+	printSlice(w, s) // len=0 cap=6 []
 
 	// Extend its length.
 	s = s[:4]
-	printSlice(s) // len=4 cap=6 [2 3 5 7]
+	printSlice(w, s) // len=4 cap=6 [2 3 5 7]
 
 	// Drop its first two values.
 	s = s[2:]
-	printSlice(s) // len=2 cap=4 [5 7]
+	printSlice(w, s) // len=2 cap=4 [5 7]
 }
 
-func printSlice(s []int) {
-	fmt.Printf("len=%d cap=%d %v\n", len(s), cap(s), s)
+func printSlice(w io.Writer, s []int) {
+	fmt.Fprintf(w, "len=%d cap=%d %v\n", len(s), cap(s), s)
 }
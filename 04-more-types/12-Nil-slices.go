@@ -3,13 +3,25 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes12() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-12",
+		Title:   "Nil slices",
+		TourURL: "https://go.dev/tour/moretypes/12",
+		Run:     RunMoreTypes12,
+	})
+}
+
+func RunMoreTypes12(w io.Writer) {
 	var s []int
-	fmt.Println(s, len(s), cap(s)) // [] 0 0
+	fmt.Fprintln(w, s, len(s), cap(s)) // [] 0 0
 	// nil slice：長度為 0，容量為 0，沒有底層 array
 	if s == nil {
-		fmt.Println("nil!")
+		fmt.Fprintln(w, "nil!")
 	} // nil!
 }
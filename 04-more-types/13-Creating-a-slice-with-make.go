@@ -3,23 +3,35 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes13() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-13",
+		Title:   "Creating a slice with make",
+		TourURL: "https://go.dev/tour/moretypes/13",
+		Run:     RunMoreTypes13,
+	})
+}
+
+func RunMoreTypes13(w io.Writer) {
 	a := make([]int, 5)
-	printSlice13("a", a) // a len=5 cap=5 [0 0 0 0 0]
+	printSlice13(w, "a", a) // a len=5 cap=5 [0 0 0 0 0]
 
 	b := make([]int, 0, 5)
-	printSlice13("b", b) // b len=0 cap=5 []
+	printSlice13(w, "b", b) // b len=0 cap=5 []
 
 	c := b[:2]
-	printSlice13("c", c) // c len=2 cap=5 [0 0]
+	printSlice13(w, "c", c) // c len=2 cap=5 [0 0]
 
 	d := c[2:5]
-	printSlice13("d", d) // d len=3 cap=3 [0 0 0]
+	printSlice13(w, "d", d) // d len=3 cap=3 [0 0 0]
 }
 
-func printSlice13(s string, x []int) {
-	fmt.Printf("%s len=%d cap=%d %v\n",
+func printSlice13(w io.Writer, s string, x []int) {
+	fmt.Fprintf(w, "%s len=%d cap=%d %v\n",
 		s, len(x), cap(x), x)
 }
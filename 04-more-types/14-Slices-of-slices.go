@@ -3,10 +3,22 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
 	"strings"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes14() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-14",
+		Title:   "Slices of slices",
+		TourURL: "https://go.dev/tour/moretypes/14",
+		Run:     RunMoreTypes14,
+	})
+}
+
+func RunMoreTypes14(w io.Writer) {
 	// Create a tic-tac-toe board.
 	board := [][]string{
 		[]string{"_", "_", "_"},
@@ -22,6 +34,6 @@ func RunMoreTypes14() {
 	board[0][2] = "X"
 
 	for i := 0; i < len(board); i++ {
-		fmt.Printf("%s\n", strings.Join(board[i], " "))
+		fmt.Fprintf(w, "%s\n", strings.Join(board[i], " "))
 	}
 }
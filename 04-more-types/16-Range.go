@@ -3,12 +3,24 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 var pow = []int{1, 2, 4, 8, 16, 32, 64, 128}
 
-func RunMoreTypes16() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-16",
+		Title:   "Range",
+		TourURL: "https://go.dev/tour/moretypes/16",
+		Run:     RunMoreTypes16,
+	})
+}
+
+func RunMoreTypes16(w io.Writer) {
 	for i, v := range pow {
-		fmt.Printf("2**%d = %d\n", i, v)
+		fmt.Fprintf(w, "2**%d = %d\n", i, v)
 	}
 }
@@ -3,9 +3,21 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes17() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-17",
+		Title:   "Range continued",
+		TourURL: "https://go.dev/tour/moretypes/17",
+		Run:     RunMoreTypes17,
+	})
+}
+
+func RunMoreTypes17(w io.Writer) {
 	pow := make([]int, 10)
 	// 只使用 index
 	for i := range pow {
@@ -13,6 +25,6 @@ func RunMoreTypes17() {
 	}
 	// 只使用 value
 	for _, value := range pow {
-		fmt.Printf("%d\n", value) // 1 2 4 8 16 32 64 128 256 512
+		fmt.Fprintf(w, "%d\n", value) // 1 2 4 8 16 32 64 128 256 512
 	}
 }
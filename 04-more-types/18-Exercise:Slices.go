@@ -3,8 +3,12 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
 
-	"golang.org/x/tour/pic"
+	"first-golang/exercise"
+	"first-golang/i18n"
+	"first-golang/registry"
+	"first-golang/tourio"
 )
 
 func Pic(dx, dy int) [][]uint8 {
@@ -29,17 +33,43 @@ func Pic(dx, dy int) [][]uint8 {
 	return picture
 }
 
-func RunMoreTypes18() {
-	// 顯示圖片（需要 golang.org/x/tour/pic 套件）
-	// 如果套件不可用，可以安裝：go get golang.org/x/tour/pic
-	pic.Show(Pic)
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-18",
+		Title:   "Exercise: Slices",
+		TourURL: "https://go.dev/tour/moretypes/18",
+		Run:     RunMoreTypes18,
+		Check:   checkMoreTypes18,
+	})
+}
+
+func RunMoreTypes18(w io.Writer) {
+	// 顯示圖片（tourio.ShowPic 與 golang.org/x/tour/pic 的 pic.Show 相同，
+	// 但輸出到 w 而不是 os.Stdout）
+	tourio.ShowPic(w, Pic)
 
 	// 也可以打印一些資訊來驗證函數是否正常工作
 	result := Pic(5, 5)
-	fmt.Println("Pic(5, 5) 的前幾行:")
+	i18n.Fprintln(w, "04-18.first-rows")
 	for i, row := range result {
 		if i < 3 {
-			fmt.Printf("  行 %d: %v\n", i, row)
+			i18n.Fprintf(w, "04-18.row", i, row)
+		}
+	}
+}
+
+// checkMoreTypes18 驗證 Pic 回傳的是 dy 列、每列 dx 個值的二維切片
+func checkMoreTypes18(t *exercise.T) {
+	for _, size := range [][2]int{{1, 1}, {3, 2}, {256, 256}} {
+		dx, dy := size[0], size[1]
+		pic := Pic(dx, dy)
+		name := fmt.Sprintf("Pic(%d, %d)", dx, dy)
+		t.Equal("len("+name+")", len(pic), dy)
+		for y, row := range pic {
+			if len(row) != dx {
+				t.Equal(fmt.Sprintf("len(%s[%d])", name, y), len(row), dx)
+				break
+			}
 		}
 	}
 }
@@ -3,6 +3,9 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 type Vertex19 struct {
@@ -11,10 +14,19 @@ type Vertex19 struct {
 
 var m map[string]Vertex19
 
-func RunMoreTypes19() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-19",
+		Title:   "Maps",
+		TourURL: "https://go.dev/tour/moretypes/19",
+		Run:     RunMoreTypes19,
+	})
+}
+
+func RunMoreTypes19(w io.Writer) {
 	m = make(map[string]Vertex19)
 	m["Bell Labs"] = Vertex19{
 		40.68433, -74.39967,
 	}
-	fmt.Println(m["Bell Labs"]) // {40.68433 -74.39967}
+	fmt.Fprintln(w, m["Bell Labs"]) // {40.68433 -74.39967}
 }
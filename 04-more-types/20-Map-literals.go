@@ -3,6 +3,9 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 type Vertex20 struct {
@@ -18,6 +21,15 @@ var m20 = map[string]Vertex20{
 	},
 }
 
-func RunMoreTypes20() {
-	fmt.Println(m20)
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-20",
+		Title:   "Map literals",
+		TourURL: "https://go.dev/tour/moretypes/20",
+		Run:     RunMoreTypes20,
+	})
+}
+
+func RunMoreTypes20(w io.Writer) {
+	fmt.Fprintln(w, m20)
 }
@@ -3,6 +3,9 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 type Vertex21 struct {
@@ -15,6 +18,15 @@ var m21 = map[string]Vertex21{
 	"Google":    {37.42202, -122.08408},
 }
 
-func RunMoreTypes21() {
-	fmt.Println(m21)
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-21",
+		Title:   "Map literals continued",
+		TourURL: "https://go.dev/tour/moretypes/21",
+		Run:     RunMoreTypes21,
+	})
+}
+
+func RunMoreTypes21(w io.Writer) {
+	fmt.Fprintln(w, m21)
 }
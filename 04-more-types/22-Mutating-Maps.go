@@ -3,20 +3,32 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMoreTypes22() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-22",
+		Title:   "Mutating Maps",
+		TourURL: "https://go.dev/tour/moretypes/22",
+		Run:     RunMoreTypes22,
+	})
+}
+
+func RunMoreTypes22(w io.Writer) {
 	m22 := make(map[string]int)
 
 	m22["Answer"] = 42
-	fmt.Println("The value:", m22["Answer"]) // The value: 42
+	fmt.Fprintln(w, "The value:", m22["Answer"]) // The value: 42
 
 	m22["Answer"] = 48
-	fmt.Println("The value:", m22["Answer"]) // The value: 48
+	fmt.Fprintln(w, "The value:", m22["Answer"]) // The value: 48
 
 	delete(m22, "Answer")
-	fmt.Println("The value:", m22["Answer"]) // The value: 0
+	fmt.Fprintln(w, "The value:", m22["Answer"]) // The value: 0
 
 	v, ok := m22["Answer"]
-	fmt.Println("The value:", v, "Present?", ok) // The value: 0 Present? false
+	fmt.Fprintln(w, "The value:", v, "Present?", ok) // The value: 0 Present? false
 }
@@ -2,9 +2,13 @@
 package moreTypes
 
 import (
+	"fmt"
+	"io"
 	"strings"
 
-	"golang.org/x/tour/wc"
+	"first-golang/exercise"
+	"first-golang/registry"
+	"first-golang/tourio"
 )
 
 func WordCount(s string) map[string]int {
@@ -17,8 +21,19 @@ func WordCount(s string) map[string]int {
 	return result
 }
 
-func RunMoreTypes23() {
-	wc.Test(WordCount)
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-23",
+		Title:   "Exercise: Maps",
+		TourURL: "https://go.dev/tour/moretypes/23",
+		Run:     RunMoreTypes23,
+		Check:   checkMoreTypes23,
+	})
+}
+
+func RunMoreTypes23(w io.Writer) {
+	// tourio.TestWordCount 與 golang.org/x/tour/wc 的 wc.Test 相同，但輸出到 w
+	tourio.TestWordCount(w, WordCount)
 
 	/*
 		PASS
@@ -38,3 +53,10 @@ func RunMoreTypes23() {
 		map[string]int{"A":1, "a":2, "canal":1, "man":1, "panama.":1, "plan":1}
 	*/
 }
+
+// checkMoreTypes23 用和 wc.Test 相同的測資驗證 WordCount
+func checkMoreTypes23(t *exercise.T) {
+	for _, c := range tourio.WordCountCases {
+		t.Equal(fmt.Sprintf("WordCount(%q)", c.In), WordCount(c.In), c.Want)
+	}
+}
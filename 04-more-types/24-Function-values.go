@@ -3,21 +3,33 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 func compute(fn func(float64, float64) float64) float64 {
 	return fn(3, 4)
 }
 
-func RunMoreTypes24() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-24",
+		Title:   "Function values",
+		TourURL: "https://go.dev/tour/moretypes/24",
+		Run:     RunMoreTypes24,
+	})
+}
+
+func RunMoreTypes24(w io.Writer) {
 	hypot := func(x, y float64) float64 {
 		return math.Sqrt(x*x + y*y)
 	}
-	fmt.Println(hypot(5, 12)) // 13
+	fmt.Fprintln(w, hypot(5, 12)) // 13
 
 	// compute 呼叫 hypot(3, 4) 計算 sqrt(3^2 + 4^2)
-	fmt.Println(compute(hypot)) // 5
+	fmt.Fprintln(w, compute(hypot)) // 5
 	// compute 呼叫 math.Pow(3, 4) 計算 3 的 4 次方
-	fmt.Println(compute(math.Pow)) // 81
+	fmt.Fprintln(w, compute(math.Pow)) // 81
 }
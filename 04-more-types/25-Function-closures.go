@@ -3,6 +3,9 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 func adder() func(int) int {
@@ -13,7 +16,16 @@ func adder() func(int) int {
 	}
 }
 
-func RunMoreTypes25() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-25",
+		Title:   "Function closures",
+		TourURL: "https://go.dev/tour/moretypes/25",
+		Run:     RunMoreTypes25,
+	})
+}
+
+func RunMoreTypes25(w io.Writer) {
 	/*
 		這樣寫的好處
 		- adder 回傳的函式可以獨立累加自己的 sum，pos 與 neg 各有自己的累積值，互不影響。
@@ -23,7 +35,7 @@ func RunMoreTypes25() {
 	pos, neg := adder(), adder()
 
 	for i := range make([]int, 10) {
-		fmt.Println(
+		fmt.Fprintln(w,
 			pos(i),
 			neg(-2*i),
 		)
@@ -3,6 +3,10 @@ package moreTypes
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/exercise"
+	"first-golang/registry"
 )
 
 func fibonacci() func() int {
@@ -14,9 +18,33 @@ func fibonacci() func() int {
 	}
 }
 
-func RunMoreTypes26() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "04-26",
+		Title:   "Exercise: Fibonacci closure",
+		TourURL: "https://go.dev/tour/moretypes/26",
+		Run:     RunMoreTypes26,
+		Check:   checkMoreTypes26,
+	})
+}
+
+func RunMoreTypes26(w io.Writer) {
 	f := fibonacci()
 	for i := 0; i < 10; i++ {
-		fmt.Println(f())
+		fmt.Fprintln(w, f())
+	}
+}
+
+// checkMoreTypes26 驗證 fibonacci 回傳的閉包依序產生 0, 1, 1, 2, 3, 5, ...
+// 且每次呼叫 fibonacci() 都從頭開始
+func checkMoreTypes26(t *exercise.T) {
+	want := []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89}
+	for run := 1; run <= 2; run++ {
+		f := fibonacci()
+		got := make([]int, len(want))
+		for i := range got {
+			got[i] = f()
+		}
+		t.Equal(fmt.Sprintf("fibonacci() call %d, first %d values", run, len(want)), got, want)
 	}
 }
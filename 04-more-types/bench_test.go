@@ -0,0 +1,25 @@
+package moreTypes
+
+import (
+	"strings"
+	"testing"
+)
+
+// BenchmarkMoreTypes18 測量 04-18 產生 256x256 圖片的速度，主要是配置每一列的成本
+func BenchmarkMoreTypes18(b *testing.B) {
+	b.Run("Pic", func(b *testing.B) {
+		for b.Loop() {
+			Pic(256, 256)
+		}
+	})
+}
+
+// BenchmarkMoreTypes23 測量 04-23 對約 900 個單字的文字計數的速度
+func BenchmarkMoreTypes23(b *testing.B) {
+	text := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 100)
+	b.Run("WordCount", func(b *testing.B) {
+		for b.Loop() {
+			WordCount(text)
+		}
+	})
+}
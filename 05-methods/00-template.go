@@ -3,8 +3,9 @@ package methods
 
 import (
 	"fmt"
+	"io"
 )
 
-func EmptyTemplate() {
-	fmt.Println("Empty template")
+func EmptyTemplate(w io.Writer) {
+	fmt.Fprintln(w, "Empty template")
 }
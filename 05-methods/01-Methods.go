@@ -3,7 +3,10 @@ package methods
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 // Vertex 是一個自訂型別（struct），我們可以在它上面定義方法
@@ -17,8 +20,17 @@ func (v Vertex) Abs() float64 {
 	return math.Sqrt(v.X*v.X + v.Y*v.Y)
 }
 
-func RunMethods01() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-01",
+		Title:   "Methods",
+		TourURL: "https://go.dev/tour/methods/1",
+		Run:     RunMethods01,
+	})
+}
+
+func RunMethods01(w io.Writer) {
 	// 雖然 Go 沒有 class，但可以透過 receiver 讓 Vertex 擁有方法
 	v := Vertex{3, 4}
-	fmt.Println(v.Abs()) // 5
+	fmt.Fprintln(w, v.Abs()) // 5
 }
@@ -3,7 +3,10 @@ package methods
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 type Vertex02 struct {
@@ -16,8 +19,17 @@ func Abs(v Vertex02) float64 {
 	return math.Sqrt(v.X*v.X + v.Y*v.Y)
 }
 
-func RunMethods02() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-02",
+		Title:   "Methods are functions",
+		TourURL: "https://go.dev/tour/methods/2",
+		Run:     RunMethods02,
+	})
+}
+
+func RunMethods02(w io.Writer) {
 	v := Vertex02{3, 4}
 	// 直接呼叫一般函數 Abs，計算結果與方法版本完全一致
-	fmt.Println(Abs(v)) // 5
+	fmt.Fprintln(w, Abs(v)) // 5
 }
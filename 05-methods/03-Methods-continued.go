@@ -3,7 +3,10 @@ package methods
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 // MyFloat 是自訂的數值型別（非 struct），仍然可以擁有方法
@@ -18,8 +21,17 @@ func (f MyFloat) Abs() float64 {
 	return float64(f)
 }
 
-func RunMethods03() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-03",
+		Title:   "Methods continued",
+		TourURL: "https://go.dev/tour/methods/3",
+		Run:     RunMethods03,
+	})
+}
+
+func RunMethods03(w io.Writer) {
 	f := MyFloat(-math.Sqrt2)
 	// 呼叫 MyFloat 的 Abs 方法，與 struct receiver 用法相同
-	fmt.Println(f.Abs()) // 1.4142135623730951
+	fmt.Fprintln(w, f.Abs()) // 1.4142135623730951
 }
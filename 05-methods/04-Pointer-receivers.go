@@ -3,7 +3,10 @@ package methods
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 type Vertex04 struct {
@@ -22,9 +25,18 @@ func (v *Vertex04) Scale(f float64) {
 	v.Y *= f
 }
 
-func RunMethods04() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-04",
+		Title:   "Pointer receivers",
+		TourURL: "https://go.dev/tour/methods/4",
+		Run:     RunMethods04,
+	})
+}
+
+func RunMethods04(w io.Writer) {
 	v := Vertex04{3, 4}
 	// 指標接收者允許我們在方法內直接調整 v 的值
 	v.Scale(10)
-	fmt.Println(v.Abs()) // 50
+	fmt.Fprintln(w, v.Abs()) // 50
 }
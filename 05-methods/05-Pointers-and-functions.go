@@ -3,7 +3,10 @@ package methods
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 type Vertex05 struct {
@@ -23,9 +26,18 @@ func Scale05(v *Vertex05, f float64) {
 	v.Y *= f
 }
 
-func RunMethods05() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-05",
+		Title:   "Pointers and functions",
+		TourURL: "https://go.dev/tour/methods/5",
+		Run:     RunMethods05,
+	})
+}
+
+func RunMethods05(w io.Writer) {
 	v := Vertex05{3, 4}
 	// 傳入 &v 讓 Scale05 能直接修改 v 的內容
 	Scale05(&v, 10)
-	fmt.Println(Abs05(v)) // 50
+	fmt.Fprintln(w, Abs05(v)) // 50
 }
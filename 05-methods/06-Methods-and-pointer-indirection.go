@@ -3,6 +3,9 @@ package methods
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 type Vertex06 struct {
@@ -23,7 +26,16 @@ func ScaleFunc(v *Vertex06, f float64) {
 	v.Y *= f
 }
 
-func RunMethods06() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-06",
+		Title:   "Methods and pointer indirection",
+		TourURL: "https://go.dev/tour/methods/6",
+		Run:     RunMethods06,
+	})
+}
+
+func RunMethods06(w io.Writer) {
 	v := Vertex06{3, 4}
 	// 雖然 v 是值，但仍可呼叫指標 receiver 方法（Go 會自動取址）
 	v.Scale(2)
@@ -35,5 +47,5 @@ func RunMethods06() {
 	p.Scale(3)
 	ScaleFunc(p, 8)
 
-	fmt.Println(v, p) // {60 80} &{96 72}
+	fmt.Fprintln(w, v, p) // {60 80} &{96 72}
 }
@@ -3,7 +3,10 @@ package methods
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 type Vertex07 struct {
@@ -21,14 +24,23 @@ func AbsFunc(v Vertex07) float64 {
 	return math.Sqrt(v.X*v.X + v.Y*v.Y)
 }
 
-func RunMethods07() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-07",
+		Title:   "Methods and pointer indirection (2)",
+		TourURL: "https://go.dev/tour/methods/7",
+		Run:     RunMethods07,
+	})
+}
+
+func RunMethods07(w io.Writer) {
 	v := Vertex07{3, 4}
-	fmt.Println(v.Abs())    // 5
-	fmt.Println(AbsFunc(v)) // 5
+	fmt.Fprintln(w, v.Abs())    // 5
+	fmt.Fprintln(w, AbsFunc(v)) // 5
 
 	p := &Vertex07{4, 3}
 	// 指標也能呼叫值 receiver 方法，Go 會自動進行 (*p).Abs()
-	fmt.Println(p.Abs()) // 5
+	fmt.Fprintln(w, p.Abs()) // 5
 	// 但函數仍需要傳值，因此必須顯式寫成 AbsFunc(*p)
-	fmt.Println(AbsFunc(*p)) // 5
+	fmt.Fprintln(w, AbsFunc(*p)) // 5
 }
@@ -3,7 +3,10 @@ package methods
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 type Vertex08 struct {
@@ -23,9 +26,18 @@ func (v *Vertex08) Abs() float64 {
 	return math.Sqrt(v.X*v.X + v.Y*v.Y)
 }
 
-func RunMethods08() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-08",
+		Title:   "Choosing a value or pointer receiver",
+		TourURL: "https://go.dev/tour/methods/8",
+		Run:     RunMethods08,
+	})
+}
+
+func RunMethods08(w io.Writer) {
 	v := &Vertex08{3, 4}
-	fmt.Printf("Before scaling: %+v, Abs: %v\n", v, v.Abs())
+	fmt.Fprintf(w, "Before scaling: %+v, Abs: %v\n", v, v.Abs())
 	v.Scale(5)
-	fmt.Printf("After scaling: %+v, Abs: %v\n", v, v.Abs())
+	fmt.Fprintf(w, "After scaling: %+v, Abs: %v\n", v, v.Abs())
 }
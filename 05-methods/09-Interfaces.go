@@ -3,28 +3,40 @@ package methods
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 type Abser09 interface {
 	Abs() float64
 }
 
-func RunMethods09() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-09",
+		Title:   "Interfaces",
+		TourURL: "https://go.dev/tour/methods/9",
+		Run:     RunMethods09,
+	})
+}
+
+func RunMethods09(w io.Writer) {
 	var a Abser09
 	f := MyFloat09(-math.Sqrt2)
 	v := Vertex09{3, 4}
 
-	a = f                // MyFloat09 有 Abs 方法（值 receiver），可直接賦值
-	fmt.Println(a.Abs()) // 1.4142135623730951
-	a = &v               // 只有 *Vertex09 實作 Abs，因此要給指標
+	a = f                    // MyFloat09 有 Abs 方法（值 receiver），可直接賦值
+	fmt.Fprintln(w, a.Abs()) // 1.4142135623730951
+	a = &v                   // 只有 *Vertex09 實作 Abs，因此要給指標
 
 	// In the following line, v is a Vertex (not *Vertex)
 	// and does NOT implement Abser.
 	// 取消註解會編譯錯誤： Vertex09 缺少 Abs 方法
 	// a = v
 
-	fmt.Println(a.Abs()) // 5
+	fmt.Fprintln(w, a.Abs()) // 5
 }
 
 type MyFloat09 float64
@@ -3,11 +3,14 @@ package methods
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 // 定義一個介面，只需列出方法簽章
 type I interface {
-	M()
+	M(w io.Writer)
 }
 
 type T struct {
@@ -16,12 +19,21 @@ type T struct {
 
 // 只要型別 T 實作了介面所需的方法（M），就被視為實作 I
 // 不需要像其他語言一樣用 implements/extends 關鍵字
-func (t T) M() {
-	fmt.Println(t.S)
+func (t T) M(w io.Writer) {
+	fmt.Fprintln(w, t.S)
+}
+
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-10",
+		Title:   "Interfaces are implemented implicitly",
+		TourURL: "https://go.dev/tour/methods/10",
+		Run:     RunMethods10,
+	})
 }
 
-func RunMethods10() {
+func RunMethods10(w io.Writer) {
 	// 將 T 指派給介面，不需額外宣告「T 實作 I」
 	var i I = T{"hello"}
-	i.M() // hello
+	i.M(w) // hello
 }
@@ -8,43 +8,55 @@ package methods
 
 import (
 	"fmt"
+	"io"
 	"math"
+
+	"first-golang/registry"
 )
 
 type I11 interface {
-	M()
+	M(w io.Writer)
 }
 
 type T11 struct {
 	S string
 }
 
-func (t *T11) M() {
-	fmt.Println(t.S)
+func (t *T11) M(w io.Writer) {
+	fmt.Fprintln(w, t.S)
 }
 
 type F11 float64
 
-func (f F11) M() {
-	fmt.Println(f)
+func (f F11) M(w io.Writer) {
+	fmt.Fprintln(w, f)
+}
+
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-11",
+		Title:   "Interface values",
+		TourURL: "https://go.dev/tour/methods/11",
+		Run:     RunMethods11,
+	})
 }
 
-func RunMethods11() {
+func RunMethods11(w io.Writer) {
 	var i I11
 
 	// interface value 可以視為 (value, type) 的元組
 	// 第一個值：具體的值（concrete value）
 	// 第二個值：具體的型別（concrete type）
 	i = &T11{"Hello"}
-	Describe11(i) // 輸出：(&{Hello}, *methods.T11) - 值是指標，型別是 *T11
-	i.M()         // 呼叫 *T11 的 M 方法
+	Describe11(w, i) // 輸出：(&{Hello}, *methods.T11) - 值是指標，型別是 *T11
+	i.M(w)           // 呼叫 *T11 的 M 方法
 
 	i = F11(math.Pi)
-	Describe11(i) // 輸出：(3.141592653589793, methods.F11) - 值是 float64，型別是 F11
-	i.M()         // 呼叫 F11 的 M 方法
+	Describe11(w, i) // 輸出：(3.141592653589793, methods.F11) - 值是 float64，型別是 F11
+	i.M(w)           // 呼叫 F11 的 M 方法
 }
 
 // Describe11 展示 interface value 的內部結構：(value, type)
-func Describe11(i I11) {
-	fmt.Printf("(%v, %T)\n", i, i)
+func Describe11(w io.Writer, i I11) {
+	fmt.Fprintf(w, "(%v, %T)\n", i, i)
 }
@@ -8,10 +8,13 @@ package methods
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 type I12 interface {
-	M()
+	M(w io.Writer)
 }
 
 type T12 struct {
@@ -20,15 +23,24 @@ type T12 struct {
 
 // M 方法優雅地處理 nil receiver 的情況
 // 在 Go 中，即使 receiver 是 nil，方法仍會被呼叫
-func (t *T12) M() {
+func (t *T12) M(w io.Writer) {
 	if t == nil {
-		fmt.Println("<nil>")
+		fmt.Fprintln(w, "<nil>")
 		return
 	}
-	fmt.Println(t.S)
+	fmt.Fprintln(w, t.S)
+}
+
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-12",
+		Title:   "Interface values with nil underlying values",
+		TourURL: "https://go.dev/tour/methods/12",
+		Run:     RunMethods12,
+	})
 }
 
-func RunMethods12() {
+func RunMethods12(w io.Writer) {
 	var i I12
 
 	// 創建一個 nil 指標
@@ -36,16 +48,16 @@ func RunMethods12() {
 	// 將 nil 指標賦值給 interface value
 	// 此時 i 不是 nil，而是 (nil, *methods.T12)
 	i = t
-	Describe12(i) // 輸出：(<nil>, *methods.T12) - interface value 本身不是 nil！
-	i.M()         // 可以安全呼叫，因為 M 方法處理了 nil 的情況
+	Describe12(w, i) // 輸出：(<nil>, *methods.T12) - interface value 本身不是 nil！
+	i.M(w)           // 可以安全呼叫，因為 M 方法處理了 nil 的情況
 
 	// 對比：正常的指標
 	i = &T12{"hello"}
-	Describe12(i) // 輸出：(&{hello}, *methods.T12)
-	i.M()         // 輸出：hello
+	Describe12(w, i) // 輸出：(&{hello}, *methods.T12)
+	i.M(w)           // 輸出：hello
 }
 
 // Describe12 展示 interface value 的內部結構
-func Describe12(i I12) {
-	fmt.Printf("(%v, %T)\n", i, i)
+func Describe12(w io.Writer, i I12) {
+	fmt.Fprintf(w, "(%v, %T)\n", i, i)
 }
@@ -12,26 +12,38 @@ package methods
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 type I13 interface {
-	M()
+	M(w io.Writer)
+}
+
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-13",
+		Title:   "Nil interface values",
+		TourURL: "https://go.dev/tour/methods/13",
+		Run:     RunMethods13,
+	})
 }
 
-func RunMethods13() {
+func RunMethods13(w io.Writer) {
 	// 宣告一個 interface variable，但沒有賦值
 	// 此時 i 是 nil interface value：(nil, nil)
 	var i I13
-	Describe13(i) // 輸出：(<nil>, <nil>) - 既沒有值，也沒有型別
+	Describe13(w, i) // 輸出：(<nil>, <nil>) - 既沒有值，也沒有型別
 
 	// 在 nil interface value 上呼叫方法會產生 runtime error (panic)
 	// 因為 Go 不知道要呼叫哪個具體型別的方法
 	// 錯誤訊息：panic: runtime error: invalid memory address or nil pointer dereference
 	// 注意：linter 會警告這是 nil dereference，但這是教學範例，用來展示這個錯誤
-	i.M() // 這行會導致 panic
+	i.M(w) // 這行會導致 panic
 }
 
 // Describe13 展示 interface value 的內部結構
-func Describe13(i I13) {
-	fmt.Printf("(%v, %T)\n", i, i)
+func Describe13(w io.Writer, i I13) {
+	fmt.Fprintf(w, "(%v, %T)\n", i, i)
 }
@@ -7,20 +7,32 @@ package methods
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMethods14() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-14",
+		Title:   "The empty interface",
+		TourURL: "https://go.dev/tour/methods/14",
+		Run:     RunMethods14,
+	})
+}
+
+func RunMethods14(w io.Writer) {
 	var i interface{} // 空介面，可承載任何值
-	Describe14(i)     // (<nil>, <nil>)
+	Describe14(w, i)  // (<nil>, <nil>)
 
 	i = 42
-	Describe14(i) // (42, int)
+	Describe14(w, i) // (42, int)
 
 	i = "hello"
-	Describe14(i) // (hello, string)
+	Describe14(w, i) // (hello, string)
 }
 
 // Describe14 顯示空介面目前包裝的值與型別
-func Describe14(i interface{}) {
-	fmt.Printf("(%v, %T)\n", i, i)
+func Describe14(w io.Writer, i interface{}) {
+	fmt.Fprintf(w, "(%v, %T)\n", i, i)
 }
@@ -8,23 +8,35 @@ package methods
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMethods15() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-15",
+		Title:   "Type assertions",
+		TourURL: "https://go.dev/tour/methods/15",
+		Run:     RunMethods15,
+	})
+}
+
+func RunMethods15(w io.Writer) {
 	var i interface{} = "hello"
 
 	// 1. 直接斷言（單一回傳值），若失敗會 panic
 	s := i.(string)
-	fmt.Println(s) // hello
+	fmt.Fprintln(w, s) // hello
 
 	// 2. 斷言並接收 ok，避免 panic
 	s, ok := i.(string)
-	fmt.Println(s, ok) // hello true
+	fmt.Fprintln(w, s, ok) // hello true
 
 	f, ok := i.(float64)
-	fmt.Println(f, ok) // 0 false（zero value + 失敗）
+	fmt.Fprintln(w, f, ok) // 0 false（zero value + 失敗）
 
 	// 3. 直接斷言錯誤型別會 panic
 	f = i.(float64) // panic: interface conversion: interface {} is string, not float64
-	fmt.Println(f)
+	fmt.Fprintln(w, f)
 }
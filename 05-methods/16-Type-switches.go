@@ -12,29 +12,41 @@ package methods
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
-func do(i interface{}) {
+func do(w io.Writer, i interface{}) {
 	// type switch：根據 i 的實際型別來執行不同的邏輯
 	// v := i.(type) 會根據 i 的實際型別來賦值給 v
 	switch v := i.(type) {
 	case int:
 		// 在這個 case 中，v 的型別是 int
 		// 可以直接使用 int 的操作（如乘法）
-		fmt.Printf("Twice %v is %v\n", v, v*2)
+		fmt.Fprintf(w, "Twice %v is %v\n", v, v*2)
 	case string:
 		// 在這個 case 中，v 的型別是 string
 		// 可以使用 string 的操作（如 len()）
-		fmt.Printf("%q is %v bytes long\n", v, len(v))
+		fmt.Fprintf(w, "%q is %v bytes long\n", v, len(v))
 	default:
 		// 沒有匹配的型別時，v 與 i 有相同的 interface 型別
 		// 可以使用 %T 來顯示型別資訊
-		fmt.Printf("I don't know about type %T!\n", v)
+		fmt.Fprintf(w, "I don't know about type %T!\n", v)
 	}
 }
 
-func RunMethods16() {
-	do(21)      // int 型別，輸出：Twice 21 is 42
-	do("hello") // string 型別，輸出："hello" is 5 bytes long
-	do(true)    // bool 型別，輸出：I don't know about type bool!
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-16",
+		Title:   "Type switches",
+		TourURL: "https://go.dev/tour/methods/16",
+		Run:     RunMethods16,
+	})
+}
+
+func RunMethods16(w io.Writer) {
+	do(w, 21)      // int 型別，輸出：Twice 21 is 42
+	do(w, "hello") // string 型別，輸出："hello" is 5 bytes long
+	do(w, true)    // bool 型別，輸出：I don't know about type bool!
 }
@@ -13,6 +13,9 @@ package methods
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 type Person struct {
@@ -26,10 +29,19 @@ func (p Person) String() string {
 	return fmt.Sprintf("%v (%v years)", p.Name, p.Age)
 }
 
-func RunMethods17() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-17",
+		Title:   "Stringers",
+		TourURL: "https://go.dev/tour/methods/17",
+		Run:     RunMethods17,
+	})
+}
+
+func RunMethods17(w io.Writer) {
 	a := Person{"Arthur Dent", 42}
 	z := Person{"Zaphod Beeblebrox", 9001}
 	// fmt.Println 會自動呼叫 Person 的 String() 方法
 	// 輸出：Arthur Dent (42 years) Zaphod Beeblebrox (9001 years)
-	fmt.Println(a, z)
+	fmt.Fprintln(w, a, z)
 }
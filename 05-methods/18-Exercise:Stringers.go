@@ -3,6 +3,10 @@ package methods
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/exercise"
+	"first-golang/registry"
 )
 
 type IPAddr [4]byte
@@ -13,7 +17,17 @@ func (ip IPAddr) String() string {
 	return fmt.Sprintf("%d.%d.%d.%d", ip[0], ip[1], ip[2], ip[3])
 }
 
-func RunMethods18() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-18",
+		Title:   "Exercise: Stringers",
+		TourURL: "https://go.dev/tour/methods/18",
+		Run:     RunMethods18,
+		Check:   checkMethods18,
+	})
+}
+
+func RunMethods18(w io.Writer) {
 	// hosts 模擬主機名稱與對應 IP 的查詢表。
 	hosts := map[string]IPAddr{
 		"loopback":  {127, 0, 0, 1},
@@ -21,6 +35,21 @@ func RunMethods18() {
 	}
 	// 使用 fmt.Printf 時會自動呼叫 IPAddr.String()，因此輸出為「a.b.c.d」格式。
 	for name, ip := range hosts {
-		fmt.Printf("%v: %v\n", name, ip)
+		fmt.Fprintf(w, "%v: %v\n", name, ip)
+	}
+}
+
+// checkMethods18 驗證 IPAddr 透過 String() 印成點分十進位
+func checkMethods18(t *exercise.T) {
+	cases := []struct {
+		ip   IPAddr
+		want string
+	}{
+		{IPAddr{127, 0, 0, 1}, "127.0.0.1"},
+		{IPAddr{8, 8, 8, 8}, "8.8.8.8"},
+		{IPAddr{255, 0, 10, 200}, "255.0.10.200"},
+	}
+	for _, c := range cases {
+		t.Equal(fmt.Sprintf("fmt.Sprint(IPAddr%v)", [4]byte(c.ip)), fmt.Sprint(c.ip), c.want)
 	}
 }
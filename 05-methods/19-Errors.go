@@ -3,7 +3,10 @@ package methods
 
 import (
 	"fmt"
+	"io"
 	"time"
+
+	"first-golang/registry"
 )
 
 // MyError 實作內建的 error 介面（只有一個 Error() string 方法）。
@@ -27,10 +30,19 @@ func run() error {
 	}
 }
 
-func RunMethods19() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-19",
+		Title:   "Errors",
+		TourURL: "https://go.dev/tour/methods/19",
+		Run:     RunMethods19,
+	})
+}
+
+func RunMethods19(w io.Writer) {
 	// 典型錯誤處理流程：呼叫函式→檢查 error 是否為 nil。
 	if err := run(); err != nil {
 		// fmt 會自動呼叫 err.Error()，顯示人類可讀的訊息。
-		fmt.Println(err)
+		fmt.Fprintln(w, err)
 	}
 }
@@ -4,7 +4,14 @@
 package methods
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"math"
+
+	"first-golang/exercise"
+	"first-golang/i18n"
+	"first-golang/registry"
 )
 
 // ErrNegativeSqrt 是一個自訂錯誤類型，用於表示對負數求平方根的錯誤
@@ -41,9 +48,19 @@ func Sqrt(x float64) (float64, error) {
 	return z, nil
 }
 
-func RunMethods20() {
-	fmt.Println("=== Exercise: Errors ===")
-	fmt.Println("測試 Sqrt 函數的錯誤處理")
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-20",
+		Title:   "Exercise: Errors",
+		TourURL: "https://go.dev/tour/methods/20",
+		Run:     RunMethods20,
+		Check:   checkMethods20,
+	})
+}
+
+func RunMethods20(w io.Writer) {
+	fmt.Fprintln(w, "=== Exercise: Errors ===")
+	i18n.Fprintln(w, "05-20.intro")
 
 	// 測試正常情況
 	testCases := []float64{2, 4, 7, -2, -4}
@@ -52,17 +69,37 @@ func RunMethods20() {
 		result, err := Sqrt(num)
 		if err != nil {
 			// 處理錯誤情況
-			fmt.Printf("Sqrt(%v) = 錯誤: %v\n", num, err)
+			i18n.Fprintf(w, "05-20.error", num, err)
 		} else {
 			// 處理成功情況
-			fmt.Printf("Sqrt(%v) = %v\n", num, result)
+			fmt.Fprintf(w, "Sqrt(%v) = %v\n", num, result)
 		}
 	}
 
-	fmt.Println("=== 說明 ===")
-	fmt.Println("1. ErrNegativeSqrt 實作 error 介面")
-	fmt.Println("2. Error() 方法中必須轉換為 float64 避免無限循環")
-	fmt.Println("3. Sqrt 函數返回 (float64, error) 兩個值")
-	fmt.Println("4. 負數輸入時返回 ErrNegativeSqrt 錯誤")
-	fmt.Println("5. 正常情況返回結果和 nil error")
+	i18n.Fprintln(w, "05-20.notes")
+	i18n.Fprintln(w, "05-20.note1")
+	i18n.Fprintln(w, "05-20.note2")
+	i18n.Fprintln(w, "05-20.note3")
+	i18n.Fprintln(w, "05-20.note4")
+	i18n.Fprintln(w, "05-20.note5")
+}
+
+// checkMethods20 驗證 Sqrt 的結果夠精確，負數時回傳 ErrNegativeSqrt
+func checkMethods20(t *exercise.T) {
+	for _, x := range []float64{1, 2, 4, 7, 100} {
+		name := fmt.Sprintf("Sqrt(%v)", x)
+		got, err := Sqrt(x)
+		if err != nil {
+			t.NoError(name, err)
+			continue
+		}
+		want := math.Sqrt(x)
+		t.True(name, math.Abs(got-want) <= 1e-9, "got %v, want %v", got, want)
+	}
+	_, err := Sqrt(-2)
+	var neg ErrNegativeSqrt
+	t.True("Sqrt(-2) error type", errors.As(err, &neg), "got error %#v, want an ErrNegativeSqrt", err)
+	if err != nil {
+		t.Equal("Sqrt(-2) error message", err.Error(), "cannot Sqrt negative number: -2")
+	}
 }
@@ -26,9 +26,20 @@ import (
 	"fmt"
 	"io"
 	"strings"
+
+	"first-golang/registry"
 )
 
-func RunMethods21() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-21",
+		Title:   "Readers",
+		TourURL: "https://go.dev/tour/methods/21",
+		Run:     RunMethods21,
+	})
+}
+
+func RunMethods21(w io.Writer) {
 	// strings.NewReader 創建一個從字串讀取資料的 Reader
 	// 它實作了 io.Reader 介面
 	r := strings.NewReader("Hello, Reader!")
@@ -49,11 +60,11 @@ func RunMethods21() {
 		// n: 讀取的位元組數
 		// err: 錯誤狀態
 		// b: 整個緩衝區（包含之前讀取的資料和新的資料）
-		fmt.Printf("n = %v err = %v b = %v\n", n, err, b)
+		fmt.Fprintf(w, "n = %v err = %v b = %v\n", n, err, b)
 
 		// b[:n] 只包含本次實際讀取的資料
 		// 使用 %q 格式化可以清楚看到字串內容
-		fmt.Printf("b[:n] = %q\n", b[:n])
+		fmt.Fprintf(w, "b[:n] = %q\n", b[:n])
 
 		// 檢查是否到達資料流結尾
 		// io.EOF 是 io 套件定義的特殊錯誤，表示「End Of File」
@@ -3,7 +3,13 @@
 // 實作一個 Reader 類型，它會發送無限的 ASCII 字元 'A' 的流
 package methods
 
-import "golang.org/x/tour/reader"
+import (
+	"io"
+
+	"first-golang/exercise"
+	"first-golang/registry"
+	"first-golang/tourio"
+)
 
 // MyReader 是一個會無限發送 'A' 字元的 Reader
 type MyReader struct{}
@@ -22,8 +28,24 @@ func (r MyReader) Read(b []byte) (int, error) {
 	return len(b), nil
 }
 
-func RunMethods22() {
-	// Validate 函數會測試 MyReader 是否正確實作了 io.Reader 介面
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-22",
+		Title:   "Exercise: Readers",
+		TourURL: "https://go.dev/tour/methods/22",
+		Run:     RunMethods22,
+		Check:   checkMethods22,
+	})
+}
+
+func RunMethods22(w io.Writer) {
+	// ValidateReader（即 golang.org/x/tour/reader 的 reader.Validate）
+	// 會測試 MyReader 是否正確實作了 io.Reader 介面
 	// 它會讀取一些資料並驗證是否都是 'A' 字元
-	reader.Validate(MyReader{}) // OK!
+	tourio.ValidateReader(w, MyReader{}) // OK!
+}
+
+// checkMethods22 用和 reader.Validate 相同的方式驗證 MyReader
+func checkMethods22(t *exercise.T) {
+	t.NoError("reader.Validate(MyReader{})", tourio.CheckReader(MyReader{}))
 }
@@ -6,8 +6,11 @@ package methods
 import (
 	"fmt"
 	"io"
-	"os"
 	"strings"
+
+	"first-golang/exercise"
+	"first-golang/i18n"
+	"first-golang/registry"
 )
 
 type rot13Reader struct {
@@ -36,12 +39,40 @@ func rot13(c byte) byte {
 	}
 }
 
-func RunMethods23() {
-	fmt.Println("=== Exercise: rot13Reader ===")
-	fmt.Println("以下輸出應該是解碼後的字串：")
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-23",
+		Title:   "Exercise: rot13Reader",
+		TourURL: "https://go.dev/tour/methods/23",
+		Run:     RunMethods23,
+		Check:   checkMethods23,
+	})
+}
+
+func RunMethods23(w io.Writer) {
+	fmt.Fprintln(w, "=== Exercise: rot13Reader ===")
+	i18n.Fprintln(w, "05-23.intro")
 
 	s := strings.NewReader("Lbh penpxrq gur pbqr!")
 	r := rot13Reader{s}
-	io.Copy(os.Stdout, &r)
-	fmt.Println()
+	io.Copy(w, &r)
+	fmt.Fprintln(w)
+}
+
+// checkMethods23 驗證 rot13Reader 能解出題目的字串，且只轉換英文字母
+func checkMethods23(t *exercise.T) {
+	cases := []struct{ in, want string }{
+		{"Lbh penpxrq gur pbqr!", "You cracked the code!"},
+		{"NOPQRSTUVWXYZABCDEFGHIJKLM", "ABCDEFGHIJKLMNOPQRSTUVWXYZ"},
+		{"123 ?!", "123 ?!"},
+	}
+	for _, c := range cases {
+		name := fmt.Sprintf("rot13Reader{%q}", c.in)
+		got, err := io.ReadAll(&rot13Reader{strings.NewReader(c.in)})
+		if err != nil {
+			t.NoError(name, err)
+			continue
+		}
+		t.Equal(name, string(got), c.want)
+	}
 }
@@ -19,16 +19,28 @@ package methods
 import (
 	"fmt"
 	"image"
+	"io"
+
+	"first-golang/registry"
 )
 
-func RunMethods24() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-24",
+		Title:   "Images",
+		TourURL: "https://go.dev/tour/methods/24",
+		Run:     RunMethods24,
+	})
+}
+
+func RunMethods24(w io.Writer) {
 	// 建立一個 100x100 的 RGBA 影像，image.Rect 會建立對應的 Rectangle。
 	m := image.NewRGBA(image.Rect(0, 0, 100, 100))
 
 	// Bounds() 符合 Image 介面需求，回傳左上(0,0)到右下(100,100) 的矩形。
-	fmt.Println("Bounds:", m.Bounds())
+	fmt.Fprintln(w, "Bounds:", m.Bounds())
 
 	// At(x, y) 取得指定像素的色彩。RGBA() 展開為 16-bit 的 RGBA 成分與 Alpha。
 	r, g, b, a := m.At(0, 0).RGBA()
-	fmt.Printf("Pixel(0,0) RGBA: R=%d G=%d B=%d A=%d\n", r, g, b, a)
+	fmt.Fprintf(w, "Pixel(0,0) RGBA: R=%d G=%d B=%d A=%d\n", r, g, b, a)
 }
@@ -6,8 +6,12 @@ package methods
 import (
 	"image"
 	"image/color"
+	"image/png"
+	"io"
 
-	"golang.org/x/tour/pic"
+	"first-golang/exercise"
+	"first-golang/registry"
+	"first-golang/tourio"
 )
 
 // Image 代表一張寬高固定的影像，實作 image.Image 介面
@@ -33,8 +37,26 @@ func (img Image) At(x, y int) color.Color {
 	return color.RGBA{v, v, 255, 255}
 }
 
-func RunMethods25() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "05-25",
+		Title:   "Exercise: Images",
+		TourURL: "https://go.dev/tour/methods/25",
+		Run:     RunMethods25,
+		Check:   checkMethods25,
+	})
+}
+
+func RunMethods25(w io.Writer) {
 	// 建立 256x256 的影像並顯示
 	m := Image{width: 256, height: 256}
-	pic.ShowImage(m)
+	tourio.ShowImage(w, m)
+}
+
+// checkMethods25 驗證 Image 是一張可以編碼成 PNG 的非空圖片
+func checkMethods25(t *exercise.T) {
+	var m image.Image = Image{width: 256, height: 256}
+	t.True("Image.Bounds()", !m.Bounds().Empty(), "bounds %v are empty", m.Bounds())
+	t.True("Image.ColorModel()", m.ColorModel() != nil, "no color model")
+	t.NoError("png.Encode(Image)", png.Encode(io.Discard, m))
 }
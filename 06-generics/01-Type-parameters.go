@@ -3,6 +3,9 @@ package generics
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 // Go 函數可以使用類型參數（type parameters）來處理多種類型
@@ -30,15 +33,24 @@ func Index[T comparable](s []T, x T) int {
 	return -1
 }
 
-func RunGenerics01() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "06-01",
+		Title:   "Type parameters",
+		TourURL: "https://go.dev/tour/generics/1",
+		Run:     RunGenerics01,
+	})
+}
+
+func RunGenerics01(w io.Writer) {
 	// Index 函數可以處理 int 類型的切片
 	si := []int{10, 20, 15, -10}
-	fmt.Println(Index(si, 15)) // 輸出: 2（15 在索引 2 的位置）
+	fmt.Fprintln(w, Index(si, 15)) // 輸出: 2（15 在索引 2 的位置）
 
 	// Index 函數也可以處理 string 類型的切片
 	// 這展示了泛型的強大之處：同一個函數可以處理不同類型的數據
 	ss := []string{"foo", "bar", "baz"}
-	fmt.Println(Index(ss, "hello")) // 輸出: -1（"hello" 不在切片中）
+	fmt.Fprintln(w, Index(ss, "hello")) // 輸出: -1（"hello" 不在切片中）
 
 	// 類型推斷：Go 編譯器可以自動推斷類型參數 T
 	// 所以不需要明確指定 Index[int](si, 15)，直接寫 Index(si, 15) 即可
@@ -3,6 +3,10 @@ package generics
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/i18n"
+	"first-golang/registry"
 )
 
 // 除了泛型函數，Go 也支持泛型類型（generic types）
@@ -63,22 +67,22 @@ func (l *List[T]) Length() int {
 }
 
 // Print 打印鏈表的所有值
-func (l *List[T]) Print() {
+func (l *List[T]) Print(w io.Writer) {
 	if l == nil {
-		fmt.Println("List is empty")
+		fmt.Fprintln(w, "List is empty")
 		return
 	}
 
 	current := l
-	fmt.Print("List: ")
+	fmt.Fprint(w, "List: ")
 	for current != nil {
-		fmt.Print(current.val)
+		fmt.Fprint(w, current.val)
 		if current.next != nil {
-			fmt.Print(" -> ")
+			fmt.Fprint(w, " -> ")
 		}
 		current = current.next
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
 // Get 獲取指定索引位置的值
@@ -112,7 +116,16 @@ func Contains[T comparable](l *List[T], val T) bool {
 	return false
 }
 
-func RunGenerics02() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "06-02",
+		Title:   "Generic types",
+		TourURL: "https://go.dev/tour/generics/2",
+		Run:     RunGenerics02,
+	})
+}
+
+func RunGenerics02(w io.Writer) {
 	// 創建一個整數類型的鏈表
 	var intList *List[int]
 
@@ -121,34 +134,34 @@ func RunGenerics02() {
 	intList = intList.Push(2)
 	intList = intList.Push(1)
 
-	fmt.Println("=== 整數鏈表 ===")
-	intList.Print()                          // List: 1 -> 2 -> 3
-	fmt.Printf("長度: %d\n", intList.Length()) // 長度: 3
+	i18n.Fprintln(w, "06-02.int-list")
+	intList.Print(w)                                  // List: 1 -> 2 -> 3
+	i18n.Fprintf(w, "06-02.length", intList.Length()) // 長度: 3
 
 	// 使用 Append 在尾部添加元素
 	intList = intList.Append(4)
 	intList = intList.Append(5)
-	intList.Print() // List: 1 -> 2 -> 3 -> 4 -> 5
+	intList.Print(w) // List: 1 -> 2 -> 3 -> 4 -> 5
 
 	// 獲取指定索引的值
 	if val, ok := intList.Get(2); ok {
-		fmt.Printf("索引 2 的值: %d\n", val) // 索引 2 的值: 3
+		i18n.Fprintf(w, "06-02.index2", val) // 索引 2 的值: 3
 	}
 
 	// 檢查是否包含某個值
-	fmt.Printf("包含 3: %v\n", Contains(intList, 3))   // 包含 3: true
-	fmt.Printf("包含 10: %v\n", Contains(intList, 10)) // 包含 10: false
+	i18n.Fprintf(w, "06-02.contains3", Contains(intList, 3))   // 包含 3: true
+	i18n.Fprintf(w, "06-02.contains10", Contains(intList, 10)) // 包含 10: false
 
-	fmt.Println()
+	fmt.Fprintln(w)
 
 	// 創建一個字符串類型的鏈表
 	var strList *List[string]
 	strList = strList.Push("world")
 	strList = strList.Push("hello")
 
-	fmt.Println("=== 字符串鏈表 ===")
-	strList.Print()                          // List: hello -> world
-	fmt.Printf("長度: %d\n", strList.Length()) // 長度: 2
+	i18n.Fprintln(w, "06-02.string-list")
+	strList.Print(w)                                  // List: hello -> world
+	i18n.Fprintf(w, "06-02.length", strList.Length()) // 長度: 2
 
 	// 展示泛型類型的強大之處：同一個 List 類型可以處理不同類型的數據
 }
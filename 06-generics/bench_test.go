@@ -0,0 +1,35 @@
+package generics
+
+import (
+	"strconv"
+	"testing"
+)
+
+// BenchmarkGenerics01 測量 06-01 在 1000 個元素的 int 與 string 切片中找最後一個元素的速度
+func BenchmarkGenerics01(b *testing.B) {
+	ints := make([]int, 1000)
+	strs := make([]string, 1000)
+	for i := range ints {
+		ints[i] = i
+		strs[i] = strconv.Itoa(i)
+	}
+	b.Run("Index", func(b *testing.B) {
+		for b.Loop() {
+			Index(ints, 999)
+			Index(strs, "999")
+		}
+	})
+}
+
+// BenchmarkGenerics02 測量 06-02 用 Append 逐一建立 100 個節點鏈表的速度
+// Append 每次都要走到尾端，所以建立整個鏈表是 O(n²)
+func BenchmarkGenerics02(b *testing.B) {
+	b.Run("List.Append", func(b *testing.B) {
+		for b.Loop() {
+			var l *List[int]
+			for i := range 100 {
+				l = l.Append(i)
+			}
+		}
+	})
+}
@@ -3,7 +3,10 @@ package concurrency
 
 import (
 	"fmt"
+	"io"
 	"time"
+
+	"first-golang/registry"
 )
 
 // Goroutine（協程）是由 Go 運行時管理的輕量級線程
@@ -11,14 +14,23 @@ import (
 // 一個程序可以輕鬆創建成千上萬個 goroutine
 
 // say 函數會打印字符串 s 五次，每次打印之間暫停 100 毫秒
-func say(s string) {
+func say(w io.Writer, s string) {
 	for i := 0; i < 5; i++ {
 		time.Sleep(100 * time.Millisecond)
-		fmt.Println(s)
+		fmt.Fprintln(w, s)
 	}
 }
 
-func RunConcurrency01() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "07-01",
+		Title:   "Goroutines",
+		TourURL: "https://go.dev/tour/concurrency/1",
+		Run:     RunConcurrency01,
+	})
+}
+
+func RunConcurrency01(w io.Writer) {
 	// 使用 go 關鍵字啟動一個新的 goroutine
 	// 語法：go f(x, y, z)
 	//
@@ -48,14 +60,14 @@ func RunConcurrency01() {
 	// │   ✓ 順序：必須等這行執行完才能執行後續代碼             │
 	// │                                                          │
 	// └─────────────────────────────────────────────────────────┘
-	go say("world") // 啟動新 goroutine，非阻塞，立即繼續執行下一行
+	go say(w, "world") // 啟動新 goroutine，非阻塞，立即繼續執行下一行
 
 	// 這行代碼在當前的 goroutine 中執行（主 goroutine）
 	// 此時兩個 goroutine 會並發運行：
 	//   - 主 goroutine 執行 say("hello")
 	//   - 新 goroutine 執行 say("world")
 	// 輸出順序可能交錯，例如：hello, world, hello, world, ...
-	say("hello") // 同步執行，阻塞直到完成
+	say(w, "hello") // 同步執行，阻塞直到完成
 
 	// 注意：如果主 goroutine 結束，所有其他 goroutine 也會被終止
 	// 為了確保新啟動的 goroutine 有時間執行，我們需要等待
@@ -3,6 +3,9 @@ package concurrency
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/registry"
 )
 
 // Channel（通道）是一個類型化的管道，用於在 goroutine 之間傳遞數據
@@ -32,7 +35,16 @@ func sum(s []int, c chan int) {
 	c <- sum // send sum to c
 }
 
-func RunConcurrency02() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "07-02",
+		Title:   "Channels",
+		TourURL: "https://go.dev/tour/concurrency/2",
+		Run:     RunConcurrency02,
+	})
+}
+
+func RunConcurrency02(w io.Writer) {
 	// 定義一個包含 6 個數字的切片
 	s := []int{7, 2, 8, -9, 4, 0}
 
@@ -56,7 +68,7 @@ func RunConcurrency02() {
 
 	// 打印兩個部分的和以及總和
 	// 例如：x=17 (7+2+8), y=-5 (-9+4+0), 總和=12
-	fmt.Println(x, y, x+y)
+	fmt.Fprintln(w, x, y, x+y)
 
 	// 執行流程說明：
 	// 1. 主 goroutine 創建 channel 和切片
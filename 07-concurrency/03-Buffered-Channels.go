@@ -3,6 +3,10 @@ package concurrency
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/i18n"
+	"first-golang/registry"
 )
 
 // 緩衝通道（Buffered Channels）
@@ -23,8 +27,17 @@ import (
 // │   - 允許異步操作：發送者可以發送多個值而不等待接收者       │
 // └─────────────────────────────────────────────────────────────┘
 
-func RunConcurrency03() {
-	fmt.Println("=== 示例 1: 正常使用緩衝通道 ===")
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "07-03",
+		Title:   "Buffered Channels",
+		TourURL: "https://go.dev/tour/concurrency/3",
+		Run:     RunConcurrency03,
+	})
+}
+
+func RunConcurrency03(w io.Writer) {
+	i18n.Fprintln(w, "07-03.example1")
 
 	// 創建一個緩衝區大小為 2 的通道
 	// 這意味著可以發送 2 個值而不阻塞（只要緩衝區未滿）
@@ -34,15 +47,15 @@ func RunConcurrency03() {
 	// 因為緩衝區大小為 2，這兩個發送操作都不會阻塞
 	ch <- 1
 	ch <- 2
-	fmt.Println("已發送 2 個值到緩衝通道（緩衝區大小為 2）")
+	i18n.Fprintln(w, "07-03.sent-two")
 
 	// 接收並打印值
-	fmt.Println("接收值:", <-ch) // 1
-	fmt.Println("接收值:", <-ch) // 2
+	i18n.Fprintln(w, "07-03.received", <-ch) // 1
+	i18n.Fprintln(w, "07-03.received", <-ch) // 2
 
-	fmt.Println()
+	fmt.Fprintln(w)
 
-	fmt.Println("=== 示例 2: 緩衝區溢出（會導致死鎖） ===")
+	i18n.Fprintln(w, "07-03.example2")
 
 	// 創建一個緩衝區大小為 2 的通道
 	ch2 := make(chan int, 2)
@@ -50,12 +63,12 @@ func RunConcurrency03() {
 	// 發送兩個值（緩衝區滿了）
 	ch2 <- 1
 	ch2 <- 2
-	fmt.Println("已發送 2 個值，緩衝區已滿")
+	i18n.Fprintln(w, "07-03.buffer-full")
 
 	// 嘗試發送第三個值
 	// 這會導致阻塞，因為緩衝區已滿且沒有接收者
 	// 如果沒有其他 goroutine 來接收，程序會死鎖（deadlock）
-	fmt.Println("嘗試發送第三個值（會阻塞，因為緩衝區已滿）...")
+	i18n.Fprintln(w, "07-03.send-third-blocks")
 
 	// 注意：在實際運行時，這行會導致死鎖
 	// 因為主 goroutine 會永遠阻塞在這裡，等待緩衝區有空間
@@ -63,41 +76,41 @@ func RunConcurrency03() {
 	// ch2 <- 3  // 取消註釋這行會導致程序死鎖
 
 	// 為了演示，我們先接收一個值，然後再發送
-	fmt.Println("先接收一個值:", <-ch2) // 接收 1，緩衝區現在有空間
-	ch2 <- 3                      // 現在可以發送第三個值了
-	fmt.Println("成功發送第三個值")
+	i18n.Fprintln(w, "07-03.receive-first", <-ch2) // 接收 1，緩衝區現在有空間
+	ch2 <- 3                                       // 現在可以發送第三個值了
+	i18n.Fprintln(w, "07-03.sent-third")
 
 	// 接收剩餘的值
-	fmt.Println("接收值:", <-ch2) // 2
-	fmt.Println("接收值:", <-ch2) // 3
+	i18n.Fprintln(w, "07-03.received", <-ch2) // 2
+	i18n.Fprintln(w, "07-03.received", <-ch2) // 3
 
-	fmt.Println()
+	fmt.Fprintln(w)
 
-	fmt.Println("=== 示例 3: 緩衝區為空時接收會阻塞 ===")
+	i18n.Fprintln(w, "07-03.example3")
 
 	ch3 := make(chan int, 2)
 
 	// 緩衝區為空時嘗試接收會阻塞
 	// 如果沒有其他 goroutine 來發送，程序會死鎖
-	fmt.Println("緩衝區為空，嘗試接收會阻塞...")
+	i18n.Fprintln(w, "07-03.empty-blocks")
 
 	// 為了演示，我們先發送一個值
 	ch3 <- 10
-	fmt.Println("已發送值 10")
+	i18n.Fprintln(w, "07-03.sent-10")
 
 	// 現在可以接收了
-	fmt.Println("接收值:", <-ch3) // 10
+	i18n.Fprintln(w, "07-03.received", <-ch3) // 10
 
 	// 如果緩衝區再次為空，接收會阻塞
-	// fmt.Println(<-ch3)  // 取消註釋這行會導致死鎖
+	// fmt.Fprintln(w, <-ch3)  // 取消註釋這行會導致死鎖
 
-	fmt.Println()
+	fmt.Fprintln(w)
 
-	fmt.Println("=== 總結 ===")
-	fmt.Println("緩衝通道的行為：")
-	fmt.Println("  - 發送：只有在緩衝區滿時才阻塞")
-	fmt.Println("  - 接收：只有在緩衝區空時才阻塞")
-	fmt.Println("  - 死鎖：當所有 goroutine 都在等待時發生（發送者等待空間，接收者等待數據）")
+	i18n.Fprintln(w, "07-03.summary")
+	i18n.Fprintln(w, "07-03.behavior")
+	i18n.Fprintln(w, "07-03.behavior-send")
+	i18n.Fprintln(w, "07-03.behavior-receive")
+	i18n.Fprintln(w, "07-03.behavior-deadlock")
 
 	// RunConcurrency03Deadlock()
 }
@@ -105,8 +118,8 @@ func RunConcurrency03() {
 // RunConcurrency03Deadlock 演示緩衝區溢出導致的死鎖
 // 警告：這個函數會導致程序死鎖，僅用於演示目的
 // 要運行此函數，請取消註釋 RunConcurrency03Deadlock() 的調用
-func RunConcurrency03Deadlock() {
-	fmt.Println("=== 演示：緩衝區溢出導致死鎖 ===")
+func RunConcurrency03Deadlock(w io.Writer) {
+	i18n.Fprintln(w, "07-03.deadlock-demo")
 
 	// 創建緩衝區大小為 2 的通道
 	ch := make(chan int, 2)
@@ -114,16 +127,16 @@ func RunConcurrency03Deadlock() {
 	// 發送兩個值，緩衝區已滿
 	ch <- 1
 	ch <- 2
-	fmt.Println("已發送 2 個值，緩衝區已滿（大小為 2）")
+	i18n.Fprintln(w, "07-03.deadlock-full")
 
 	// 嘗試發送第三個值
 	// 這會導致主 goroutine 永遠阻塞在這裡
 	// 因為緩衝區已滿，且沒有其他 goroutine 來接收值
 	// 程序會死鎖，Go 運行時會檢測到並報錯：
 	// "fatal error: all goroutines are asleep - deadlock!"
-	fmt.Println("嘗試發送第三個值（會導致死鎖）...")
+	i18n.Fprintln(w, "07-03.deadlock-send")
 	ch <- 3 // 這行會導致死鎖
 
 	// 這行永遠不會執行
-	fmt.Println("這行永遠不會執行")
+	i18n.Fprintln(w, "07-03.deadlock-never")
 }
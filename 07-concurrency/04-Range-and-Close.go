@@ -3,6 +3,10 @@ package concurrency
 
 import (
 	"fmt"
+	"io"
+
+	"first-golang/i18n"
+	"first-golang/registry"
 )
 
 // Channel 的關閉（Close）和 Range 循環
@@ -37,8 +41,17 @@ func fibonacci(n int, c chan int) {
 	close(c)
 }
 
-func RunConcurrency04() {
-	fmt.Println("=== 示例 1: 使用 Range 循環接收值 ===")
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "07-04",
+		Title:   "Range and Close",
+		TourURL: "https://go.dev/tour/concurrency/4",
+		Run:     RunConcurrency04,
+	})
+}
+
+func RunConcurrency04(w io.Writer) {
+	i18n.Fprintln(w, "07-04.example1")
 
 	// 創建一個緩衝區大小為 10 的 channel
 	c := make(chan int, 10)
@@ -51,12 +64,12 @@ func RunConcurrency04() {
 	// range 會自動接收值，直到 channel 被關閉
 	// 當 channel 關閉且所有值都被接收後，循環會自動結束
 	for i := range c {
-		fmt.Println(i)
+		fmt.Fprintln(w, i)
 	}
 
-	fmt.Println()
+	fmt.Fprintln(w)
 
-	fmt.Println("=== 示例 2: 手動檢測 Channel 是否關閉 ===")
+	i18n.Fprintln(w, "07-04.example2")
 
 	ch := make(chan int, 3)
 	ch <- 1
@@ -69,15 +82,15 @@ func RunConcurrency04() {
 		v, ok := <-ch
 		if !ok {
 			// ok 為 false 表示 channel 已關閉且沒有更多值
-			fmt.Println("Channel 已關閉，沒有更多值")
+			i18n.Fprintln(w, "07-04.closed")
 			break
 		}
-		fmt.Println("接收值:", v)
+		i18n.Fprintln(w, "07-04.received", v)
 	}
 
-	fmt.Println()
+	fmt.Fprintln(w)
 
-	fmt.Println("=== 示例 3: 從已關閉的 Channel 接收 ===")
+	i18n.Fprintln(w, "07-04.example3")
 
 	ch2 := make(chan int, 2)
 	ch2 <- 10
@@ -87,17 +100,17 @@ func RunConcurrency04() {
 	// 從已關閉的 channel 接收值是可以的
 	// 會返回零值和 false
 	v1, ok1 := <-ch2
-	fmt.Printf("值: %d, 是否還有值: %v\n", v1, ok1) // 值: 10, 是否還有值: true
+	i18n.Fprintf(w, "07-04.value-ok", v1, ok1) // 值: 10, 是否還有值: true
 
 	v2, ok2 := <-ch2
-	fmt.Printf("值: %d, 是否還有值: %v\n", v2, ok2) // 值: 20, 是否還有值: true
+	i18n.Fprintf(w, "07-04.value-ok", v2, ok2) // 值: 20, 是否還有值: true
 
 	v3, ok3 := <-ch2
-	fmt.Printf("值: %d, 是否還有值: %v\n", v3, ok3) // 值: 0, 是否還有值: false（channel 已關閉）
+	i18n.Fprintf(w, "07-04.value-ok", v3, ok3) // 值: 0, 是否還有值: false（channel 已關閉）
 
-	fmt.Println()
+	fmt.Fprintln(w)
 
-	fmt.Println("=== 示例 4: 在已關閉的 Channel 上發送會導致 Panic ===")
+	i18n.Fprintln(w, "07-04.example4")
 
 	ch3 := make(chan int, 2)
 	ch3 <- 1
@@ -107,15 +120,15 @@ func RunConcurrency04() {
 	// 取消註釋下面這行會導致程序崩潰：
 	// ch3 <- 2 // panic: send on closed channel
 
-	fmt.Println("注意：在已關閉的 channel 上發送會導致 panic")
+	i18n.Fprintln(w, "07-04.send-panics")
 
-	fmt.Println()
+	fmt.Fprintln(w)
 
-	fmt.Println("=== 總結 ===")
-	fmt.Println("1. 只有發送者應該關閉 channel")
-	fmt.Println("2. 使用 close(ch) 關閉 channel")
-	fmt.Println("3. 使用 v, ok := <-ch 檢測 channel 是否關閉")
-	fmt.Println("4. 使用 for i := range c 循環接收值直到 channel 關閉")
-	fmt.Println("5. 在已關閉的 channel 上發送會導致 panic")
-	fmt.Println("6. 從已關閉的 channel 接收會返回零值和 false")
+	i18n.Fprintln(w, "07-04.summary")
+	i18n.Fprintln(w, "07-04.summary1")
+	i18n.Fprintln(w, "07-04.summary2")
+	i18n.Fprintln(w, "07-04.summary3")
+	i18n.Fprintln(w, "07-04.summary4")
+	i18n.Fprintln(w, "07-04.summary5")
+	i18n.Fprintln(w, "07-04.summary6")
 }
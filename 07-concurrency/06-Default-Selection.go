@@ -3,7 +3,11 @@ package concurrency
 
 import (
 	"fmt"
+	"io"
 	"time"
+
+	"first-golang/i18n"
+	"first-golang/registry"
 )
 
 // Select 語句中的 Default Case
@@ -30,13 +34,22 @@ import (
 // - 在等待多個 channel 的同時執行其他工作
 // - 實現超時和定期檢查
 
-func RunConcurrency06() {
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "07-06",
+		Title:   "Default Selection",
+		TourURL: "https://go.dev/tour/concurrency/6",
+		Run:     RunConcurrency06,
+	})
+}
+
+func RunConcurrency06(w io.Writer) {
 	// RunConcurrency06Simple()
 
-	fmt.Println("=== 示例：使用 Default Case 實現非阻塞操作 ===")
-	fmt.Println("每 100ms 會收到 tick，500ms 後會收到 boom")
-	fmt.Println("在沒有事件時，default case 會執行並打印 '.'")
-	fmt.Println()
+	i18n.Fprintln(w, "07-06.example")
+	i18n.Fprintln(w, "07-06.intro-tick")
+	i18n.Fprintln(w, "07-06.intro-default")
+	fmt.Fprintln(w)
 
 	start := time.Now()
 
@@ -55,16 +68,16 @@ func RunConcurrency06() {
 		select {
 		case <-tick:
 			// 每 100ms 執行一次（當 tick channel 有值時）
-			fmt.Printf("[%6s] tick.\n", elapsed())
+			fmt.Fprintf(w, "[%6s] tick.\n", elapsed())
 		case <-boom:
 			// 500ms 後執行一次，然後退出
-			fmt.Printf("[%6s] BOOM!\n", elapsed())
+			fmt.Fprintf(w, "[%6s] BOOM!\n", elapsed())
 			return
 		default:
 			// 如果 tick 和 boom 都沒有準備好（都阻塞）
 			// 立即執行 default case，不會阻塞
 			// 這使得程序可以在等待事件的同時執行其他工作
-			fmt.Printf("[%6s]     .\n", elapsed())
+			fmt.Fprintf(w, "[%6s]     .\n", elapsed())
 			time.Sleep(50 * time.Millisecond)
 		}
 		// 注意：如果沒有 default case，select 會阻塞直到 tick 或 boom 有值
@@ -81,8 +94,8 @@ func RunConcurrency06() {
 }
 
 // RunConcurrency06Simple 簡單示例：非阻塞的 channel 操作
-func RunConcurrency06Simple() {
-	fmt.Println("=== 簡單示例：非阻塞的 Channel 操作 ===")
+func RunConcurrency06Simple(w io.Writer) {
+	i18n.Fprintln(w, "07-06.simple")
 
 	c := make(chan int)
 
@@ -90,28 +103,28 @@ func RunConcurrency06Simple() {
 	select {
 	case i := <-c:
 		// 如果 channel 有值，接收並使用
-		fmt.Println("接收到值:", i)
+		i18n.Fprintln(w, "07-06.received", i)
 	default:
 		// 如果 channel 為空（接收會阻塞），執行 default
-		fmt.Println("Channel 為空，接收會阻塞，執行 default")
+		i18n.Fprintln(w, "07-06.empty")
 	}
 
 	// 嘗試非阻塞地發送
 	select {
 	case c <- 42:
 		// 如果有接收者在等待或緩衝區有空間，發送成功
-		fmt.Println("發送成功")
+		i18n.Fprintln(w, "07-06.sent")
 	default:
 		// 如果沒有接收者且緩衝區已滿（發送會阻塞），執行 default
-		fmt.Println("Channel 未準備好，發送會阻塞，執行 default")
+		i18n.Fprintln(w, "07-06.not-ready")
 	}
 
-	fmt.Println()
-	fmt.Println("對比：沒有 default 的 select 會阻塞")
+	fmt.Fprintln(w)
+	i18n.Fprintln(w, "07-06.contrast")
 	// 如果沒有 default，select 會一直阻塞直到可以執行
 	// 取消註釋下面這行會導致程序永遠阻塞：
 	// select {
 	// case i := <-c:
-	// 	fmt.Println("接收到:", i)
+	// 	fmt.Fprintln(w, "接收到:", i)
 	// }
 }
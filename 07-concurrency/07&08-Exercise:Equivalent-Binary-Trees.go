@@ -5,7 +5,11 @@ package concurrency
 
 import (
 	"fmt"
+	"io"
 
+	"first-golang/exercise"
+	"first-golang/i18n"
+	"first-golang/registry"
 	"golang.org/x/tour/tree"
 )
 
@@ -82,8 +86,18 @@ func Same(t1, t2 *tree.Tree) bool {
 	}
 }
 
-func RunConcurrency07() {
-	fmt.Println("=== 測試 Walk 函數 ===")
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "07-07",
+		Title:   "Exercise: Equivalent Binary Trees",
+		TourURL: "https://go.dev/tour/concurrency/7",
+		Run:     RunConcurrency07,
+		Check:   checkConcurrency07,
+	})
+}
+
+func RunConcurrency07(w io.Writer) {
+	i18n.Fprintln(w, "07-07.test-walk")
 
 	// tree.New(k) 構造一個隨機結構（但總是排序的）二叉樹
 	// 存儲值 k, 2k, 3k, ..., 10k
@@ -96,40 +110,59 @@ func RunConcurrency07() {
 
 	// 讀取並打印 10 個值
 	// 應該輸出數字 1, 2, 3, ..., 10（按順序）
-	fmt.Print("Walk 結果: ")
+	i18n.Fprint(w, "07-07.walk-result")
 	for i := range ch {
-		fmt.Printf("%d ", i)
+		fmt.Fprintf(w, "%d ", i)
 	}
-	fmt.Println()
-	fmt.Println()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
 
-	fmt.Println("=== 測試 Same 函數 ===")
+	i18n.Fprintln(w, "07-07.test-same")
 
 	// Same(tree.New(1), tree.New(1)) 應該返回 true
 	// 因為兩棵樹都包含相同的值序列（1, 2, 3, ..., 10）
 	result1 := Same(tree.New(1), tree.New(1))
-	fmt.Printf("Same(tree.New(1), tree.New(1)) = %v (期望: true)\n", result1)
+	i18n.Fprintf(w, "07-07.same-1-1", result1)
 
 	// Same(tree.New(1), tree.New(2)) 應該返回 false
 	// 因為第一棵樹包含 1, 2, 3, ..., 10
 	// 而第二棵樹包含 2, 4, 6, ..., 20
 	result2 := Same(tree.New(1), tree.New(2))
-	fmt.Printf("Same(tree.New(1), tree.New(2)) = %v (期望: false)\n", result2)
+	i18n.Fprintf(w, "07-07.same-1-2", result2)
 
 	// 額外測試：相同值的不同結構
-	fmt.Println()
-	fmt.Println("=== 額外測試 ===")
+	fmt.Fprintln(w)
+	i18n.Fprintln(w, "07-07.extra")
 
 	// 創建兩棵結構不同但值相同的樹
 	t1 := tree.New(1)
 	t2 := tree.New(1)
 	result3 := Same(t1, t2)
-	fmt.Printf("Same(兩棵結構不同但值相同的樹) = %v (期望: true)\n", result3)
-
-	fmt.Println()
-	fmt.Println("=== 實現說明 ===")
-	fmt.Println("1. Walk 函數使用中序遍歷（左-根-右）來按順序發送值")
-	fmt.Println("2. Same 函數同時遍歷兩棵樹，逐個比較值")
-	fmt.Println("3. 使用 goroutine 和 channel 實現並發遍歷和比較")
-	fmt.Println("4. 如果值序列完全相同，返回 true；否則返回 false")
+	i18n.Fprintf(w, "07-07.same-shapes", result3)
+
+	fmt.Fprintln(w)
+	i18n.Fprintln(w, "07-07.notes")
+	i18n.Fprintln(w, "07-07.note1")
+	i18n.Fprintln(w, "07-07.note2")
+	i18n.Fprintln(w, "07-07.note3")
+	i18n.Fprintln(w, "07-07.note4")
+}
+
+// checkConcurrency07 驗證 Walk 依序送出 k, 2k, ..., 10k，且 Same 能分辨不同的樹
+func checkConcurrency07(t *exercise.T) {
+	for k := 1; k <= 3; k++ {
+		ch := make(chan int)
+		go walkHelper(tree.New(k), ch)
+		var got []int
+		for v := range ch {
+			got = append(got, v)
+		}
+		want := make([]int, 10)
+		for i := range want {
+			want[i] = (i + 1) * k
+		}
+		t.Equal(fmt.Sprintf("Walk(tree.New(%d))", k), got, want)
+	}
+	t.Equal("Same(tree.New(1), tree.New(1))", Same(tree.New(1), tree.New(1)), true)
+	t.Equal("Same(tree.New(1), tree.New(2))", Same(tree.New(1), tree.New(2)), false)
 }
@@ -3,8 +3,12 @@ package concurrency
 
 import (
 	"fmt"
+	"io"
 	"sync"
 	"time"
+
+	"first-golang/i18n"
+	"first-golang/registry"
 )
 
 // 互斥鎖（Mutex）和同步
@@ -52,10 +56,19 @@ func (c *SafeCounter) Value(key string) int {
 	return c.v[key]
 }
 
-func RunConcurrency09() {
-	fmt.Println("=== 示例：使用 Mutex 保護共享數據 ===")
-	fmt.Println("啟動 1000 個 goroutine 同時遞增計數器")
-	fmt.Println()
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "07-09",
+		Title:   "sync.Mutex",
+		TourURL: "https://go.dev/tour/concurrency/9",
+		Run:     RunConcurrency09,
+	})
+}
+
+func RunConcurrency09(w io.Writer) {
+	i18n.Fprintln(w, "07-09.example")
+	i18n.Fprintln(w, "07-09.intro")
+	fmt.Fprintln(w)
 
 	// 創建一個 SafeCounter 實例
 	c := SafeCounter{v: make(map[string]int)}
@@ -72,20 +85,20 @@ func RunConcurrency09() {
 
 	// 讀取最終值
 	// 由於使用了互斥鎖，結果應該是 1000（每個 goroutine 遞增一次）
-	fmt.Printf("最終計數值: %d (期望: 1000)\n", c.Value("somekey"))
+	i18n.Fprintf(w, "07-09.final-count", c.Value("somekey"))
 
-	fmt.Println()
-	fmt.Println("=== 對比：沒有 Mutex 的情況 ===")
-	fmt.Println("如果沒有互斥鎖保護，多個 goroutine 同時修改 map 會導致：")
-	fmt.Println("1. 數據競爭（data race）")
-	fmt.Println("2. 不確定的結果（可能少於 1000）")
-	fmt.Println("3. 程序可能崩潰或產生錯誤的數據")
+	fmt.Fprintln(w)
+	i18n.Fprintln(w, "07-09.without")
+	i18n.Fprintln(w, "07-09.without-intro")
+	i18n.Fprintln(w, "07-09.without1")
+	i18n.Fprintln(w, "07-09.without2")
+	i18n.Fprintln(w, "07-09.without3")
 
-	fmt.Println()
-	fmt.Println("=== Mutex 使用要點 ===")
-	fmt.Println("1. 在訪問共享資源前調用 Lock()")
-	fmt.Println("2. 訪問完成後調用 Unlock()")
-	fmt.Println("3. 使用 defer Unlock() 可以確保鎖一定會被釋放")
-	fmt.Println("4. 鎖的持有時間應該盡可能短，避免影響並發性能")
-	fmt.Println("5. 不要忘記解鎖，否則會導致死鎖")
+	fmt.Fprintln(w)
+	i18n.Fprintln(w, "07-09.tips")
+	i18n.Fprintln(w, "07-09.tip1")
+	i18n.Fprintln(w, "07-09.tip2")
+	i18n.Fprintln(w, "07-09.tip3")
+	i18n.Fprintln(w, "07-09.tip4")
+	i18n.Fprintln(w, "07-09.tip5")
 }
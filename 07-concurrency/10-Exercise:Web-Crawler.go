@@ -2,8 +2,17 @@
 package concurrency
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
+
+	"first-golang/exercise"
+	"first-golang/i18n"
+	"first-golang/registry"
 )
 
 // 練習：並行網頁爬蟲
@@ -45,32 +54,69 @@ func (c *urlCache) tryMarkVisited(url string) bool {
 	return true        // 成功標記
 }
 
+// ContextFetcher 是可以取消的 Fetcher
+// ctx 被取消或超過期限時，FetchContext 應該盡快放棄抓取並回傳 ctx.Err()
+type ContextFetcher interface {
+	Fetcher
+	FetchContext(ctx context.Context, url string) (body string, urls []string, err error)
+}
+
+// fetch 用 fetcher 抓取 url；fetcher 也實作 ContextFetcher 時改用 FetchContext，
+// 否則只能在抓取前後檢查 ctx，無法中斷進行中的 Fetch
+func fetch(ctx context.Context, fetcher Fetcher, url string) (string, []string, error) {
+	if cf, ok := fetcher.(ContextFetcher); ok {
+		return cf.FetchContext(ctx, url)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+	body, urls, err := fetcher.Fetch(url)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return "", nil, ctxErr
+	}
+	return body, urls, err
+}
+
 // Crawl 使用 fetcher 遞歸爬取從 url 開始的頁面，最大深度為 depth
 // 修改為並行版本，使用 goroutine 並行獲取 URL，並使用緩存避免重複獲取
-func Crawl(url string, depth int, fetcher Fetcher, cache *urlCache) {
-	// 如果深度小於等於 0，停止遞歸
-	if depth <= 0 {
-		return
+// Crawl 保留 Go Tour 練習的簽章，結果只會以 "found:" 行印到 w，不會回傳或送出任何結構化的結果；
+// 程式中需要結果時，請改用取代它的 Crawler：Collect 回傳每頁的 Page（深度、來源、連結與錯誤）與
+// CrawlSummary，Stream 則用 channel 逐頁送出
+func Crawl(w io.Writer, url string, depth int, fetcher Fetcher, cache *urlCache) {
+	CrawlContext(context.Background(), w, url, depth, fetcher, cache)
+}
+
+// CrawlContext 與 Crawl 相同（同樣只印出結果，結構化的結果請用 Crawler），但可以用 ctx 提早結束整個爬取
+// ctx 被取消或超過期限後，不會再開始新的抓取，進行中的抓取也會被中斷（fetcher 需實作 ContextFetcher），
+// 回傳時所有 goroutine 都已結束；爬取被中斷時回傳 ctx.Err()，否則回傳 nil
+func CrawlContext(ctx context.Context, w io.Writer, url string, depth int, fetcher Fetcher, cache *urlCache) error {
+	// 如果深度小於等於 0，或爬取已被取消，停止遞歸
+	if depth <= 0 || ctx.Err() != nil {
+		return ctx.Err()
 	}
 
 	// 原子地嘗試標記 URL 為已訪問
 	// 如果 URL 已經被訪問過，直接返回，避免重複獲取
 	// 這確保了在並發環境下不會重複獲取同一個 URL
 	if !cache.tryMarkVisited(url) {
-		return // URL 已經被訪問過，直接返回
+		return nil // URL 已經被訪問過，直接返回
 	}
 
 	// 獲取 URL 的內容
-	body, urls, err := fetcher.Fetch(url)
+	body, urls, err := fetch(ctx, fetcher, url)
+	if ctx.Err() != nil {
+		return ctx.Err() // 被取消的抓取不是頁面的錯誤，不必印出
+	}
 	if err != nil {
-		fmt.Println(err)
-		return
+		fmt.Fprintln(w, err)
+		return nil
 	}
 
 	// 打印找到的內容
-	fmt.Printf("found: %s %q\n", url, body)
+	fmt.Fprintf(w, "found: %s %q\n", url, body)
 
 	// 使用 WaitGroup 等待所有子 goroutine 完成
+	// 即使被取消也要等，這樣回傳後就不會留下還在跑的 goroutine
 	var wg sync.WaitGroup
 
 	// 並行處理所有找到的 URL
@@ -79,32 +125,70 @@ func Crawl(url string, depth int, fetcher Fetcher, cache *urlCache) {
 		go func(u string) {
 			defer wg.Done() // goroutine 完成時減少計數
 			// 遞歸爬取子 URL，深度減 1
-			Crawl(u, depth-1, fetcher, cache)
+			CrawlContext(ctx, w, u, depth-1, fetcher, cache)
 		}(u) // 注意：必須傳遞 u 作為參數，避免閉包問題
 	}
 
 	// 等待所有子 goroutine 完成
 	wg.Wait()
+	return ctx.Err()
+}
+
+func init() {
+	registry.Register(registry.Lesson{
+		Code:    "07-10",
+		Title:   "Exercise: Web Crawler",
+		TourURL: "https://go.dev/tour/concurrency/10",
+		Run:     RunConcurrency10,
+		Check:   checkConcurrency10,
+	})
 }
 
-func RunConcurrency10() {
-	fmt.Println("=== 並行網頁爬蟲示例 ===")
-	fmt.Println("使用 goroutine 並行獲取 URL，並使用 mutex 保護 URL 緩存")
-	fmt.Println()
+func RunConcurrency10(w io.Writer) {
+	i18n.Fprintln(w, "07-10.example")
+	i18n.Fprintln(w, "07-10.intro")
+	fmt.Fprintln(w)
 
 	// 創建 URL 緩存
 	cache := newURLCache()
 
 	// 開始爬取，從 "https://golang.org/" 開始，最大深度為 4
-	Crawl("https://golang.org/", 4, fetcher, cache)
-
-	fmt.Println()
-	fmt.Println("=== 實現說明 ===")
-	fmt.Println("1. 使用 sync.Mutex 保護 URL 緩存 map，確保並發安全")
-	fmt.Println("2. 在獲取 URL 前檢查緩存，避免重複獲取")
-	fmt.Println("3. 使用 goroutine 並行處理所有子 URL")
-	fmt.Println("4. 使用 sync.WaitGroup 等待所有 goroutine 完成")
-	fmt.Println("5. 遞歸深度控制確保不會無限遞歸")
+	Crawl(w, "https://golang.org/", 4, fetcher, cache)
+
+	fmt.Fprintln(w)
+	i18n.Fprintln(w, "07-10.notes")
+	i18n.Fprintln(w, "07-10.note1")
+	i18n.Fprintln(w, "07-10.note2")
+	i18n.Fprintln(w, "07-10.note3")
+	i18n.Fprintln(w, "07-10.note4")
+	i18n.Fprintln(w, "07-10.note5")
+}
+
+// checkConcurrency10 驗證 Crawl 對每個網址只抓取一次，並找到所有可達的頁面
+func checkConcurrency10(t *exercise.T) {
+	// 遞迴的 Crawl 會從多個 goroutine 同時寫入 w，io.Pipe 讓每次寫入依序完成
+	pr, pw := io.Pipe()
+	go func() {
+		Crawl(pw, "https://golang.org/", 4, fetcher, newURLCache())
+		pw.Close()
+	}()
+	out, _ := io.ReadAll(pr)
+
+	found := make(map[string]int)
+	notFound := 0
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if rest, ok := strings.CutPrefix(line, "found: "); ok {
+			url, _, _ := strings.Cut(rest, " ")
+			found[url]++
+		} else if strings.HasPrefix(line, "not found: ") {
+			notFound++
+		}
+	}
+	for _, url := range slices.Sorted(maps.Keys(fetcher)) {
+		t.Equal("times "+url+" was fetched", found[url], 1)
+	}
+	t.Equal("pages found", len(found), len(fetcher))
+	t.Equal("missing pages reported", notFound, 1)
 }
 
 // fakeFetcher is Fetcher that returns canned results.
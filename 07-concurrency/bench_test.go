@@ -0,0 +1,35 @@
+package concurrency
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"golang.org/x/tour/tree"
+)
+
+// BenchmarkConcurrency07 測量 07-07 比較兩棵值相同的樹的速度，包含 goroutine 與 channel 的成本
+func BenchmarkConcurrency07(b *testing.B) {
+	t1, t2 := tree.New(1), tree.New(1)
+	b.Run("Same", func(b *testing.B) {
+		for b.Loop() {
+			Same(t1, t2)
+		}
+	})
+}
+
+// BenchmarkConcurrency10 測量 07-10 用假的 fetcher 爬完整個網站的速度，每次都用新的快取，
+// 並與使用 worker pool 的 Crawler 比較
+func BenchmarkConcurrency10(b *testing.B) {
+	b.Run("Crawl", func(b *testing.B) {
+		for b.Loop() {
+			Crawl(io.Discard, "https://golang.org/", 4, fetcher, newURLCache())
+		}
+	})
+	b.Run("Crawler", func(b *testing.B) {
+		c := &Crawler{Fetcher: fetcher}
+		for b.Loop() {
+			c.Crawl(context.Background(), io.Discard, "https://golang.org/", 4)
+		}
+	})
+}
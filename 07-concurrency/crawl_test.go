@@ -0,0 +1,67 @@
+package concurrency
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCrawlContextDeadline(t *testing.T) {
+	// 超過期限時，CrawlContext 要中斷卡住的抓取並盡快回傳，不留下 goroutine
+	slow := &blockingFetcher{}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := CrawlContext(ctx, io.Discard, "https://golang.org/", 4, slow, newURLCache())
+	if err != context.DeadlineExceeded {
+		t.Errorf("CrawlContext = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("CrawlContext took %v after the deadline", d)
+	}
+	if n := slow.running.Load(); n != 0 {
+		t.Errorf("%d fetches still running", n)
+	}
+}
+
+func TestCrawlContextCancelsHTTP(t *testing.T) {
+	// 取消時，NewHTTPFetcher 進行中的請求也要中斷
+	hang := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer hang.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	err := CrawlContext(ctx, io.Discard, hang.URL+"/", 4, NewHTTPFetcher(hang.Client()), newURLCache())
+	if err != context.Canceled {
+		t.Errorf("CrawlContext = %v, want %v", err, context.Canceled)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("CrawlContext took %v after cancel", d)
+	}
+}
+
+// blockingFetcher 的每個頁面都連到兩個新頁面，而且除了第一頁之外，抓取都會卡住直到 ctx 結束
+// running 記錄進行中的抓取數量，用來確認取消後沒有留下 goroutine
+type blockingFetcher struct {
+	running atomic.Int32
+}
+
+func (f *blockingFetcher) Fetch(url string) (string, []string, error) {
+	return f.FetchContext(context.Background(), url)
+}
+
+func (f *blockingFetcher) FetchContext(ctx context.Context, url string) (string, []string, error) {
+	f.running.Add(1)
+	defer f.running.Add(-1)
+	if url != "https://golang.org/" {
+		<-ctx.Done()
+		return "", nil, ctx.Err()
+	}
+	return "The Go Programming Language", []string{url + "a/", url + "b/"}, nil
+}
@@ -0,0 +1,190 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// 用固定數量的 worker 爬取網站，取代練習中「每個連結一個 goroutine」的遞迴 Crawl
+//
+// 遞迴版本在連結很多的網站上會同時開出無上限的 goroutine 與連線；
+// Crawler 只開 Workers 個 goroutine 負責抓取，由呼叫者所在的 goroutine 擔任調度者：
+// 它持有待抓佇列（frontier）與已看過的網址，把工作交給閒置的 worker，
+// 收到結果後交出一個 Page 並把新發現的連結排進佇列，直到佇列清空且沒有進行中的抓取
+//
+// 結果有三種取法：Collect 回傳全部頁面與摘要，Stream 透過 channel 逐頁送出，
+// Crawl 則像遞迴版本一樣把每頁印成一行
+
+// defaultWorkers 是 Workers 未設定時同時進行的抓取數
+const defaultWorkers = 4
+
+// Crawler 以固定大小的 worker pool 爬取從某個網址開始的頁面
+type Crawler struct {
+	Fetcher Fetcher
+	Workers int // 同時進行的抓取上限，<= 0 時為 defaultWorkers
+
+	// Politeness 限制對每個主機的請求，零值表示不限制
+	Politeness Politeness
+}
+
+// Page 是爬取一個網址的結果
+type Page struct {
+	URL    string
+	Body   string
+	Depth  int      // 與起點的距離，起點為 0
+	Parent string   // 第一個連到這頁的頁面，起點為空字串
+	Links  []string // 頁面上的所有連結，包括已經爬過或超過深度而沒有爬的
+	Err    error    // 抓取失敗的原因，成功時為 nil
+}
+
+// String 回傳遞迴的 Crawl 會印出的那一行：成功時為 "found: <url> <body>"，失敗時為錯誤訊息
+func (p Page) String() string {
+	if p.Err != nil {
+		return p.Err.Error()
+	}
+	return fmt.Sprintf("found: %s %q", p.URL, p.Body)
+}
+
+// CrawlSummary 總結一次爬取
+type CrawlSummary struct {
+	Pages   int // 成功抓取的頁面數
+	Errors  int // 抓取失敗的網址數
+	Elapsed time.Duration
+}
+
+func (s CrawlSummary) String() string {
+	return fmt.Sprintf("%d pages, %d errors in %v", s.Pages, s.Errors, s.Elapsed.Round(time.Millisecond))
+}
+
+// CrawlResult 是 Collect 的結果
+type CrawlResult struct {
+	Pages   []Page // 依抓取完成的順序
+	Summary CrawlSummary
+}
+
+// crawlTask 是佇列中的一個網址
+type crawlTask struct {
+	url    string
+	parent string
+	depth  int // 與起點的距離
+}
+
+// Crawl 從 url 開始爬取，最大深度為 depth，輸出與遞迴的 Crawl 相同：每個頁面一行 Page.String()
+// 所有輸出都由呼叫者的 goroutine 寫入 w，不會有多個 goroutine 同時寫入
+// 取消與回傳值的規則與 Collect 相同
+func (c *Crawler) Crawl(ctx context.Context, w io.Writer, url string, depth int) error {
+	return c.walk(ctx, url, depth, func(p Page) {
+		fmt.Fprintln(w, p)
+	})
+}
+
+// Collect 從 url 開始爬取，最大深度為 depth，回傳所有頁面與摘要
+// 每個網址只抓一次，深度以第一次發現它時為準，這點與遞迴的 Crawl 相同
+//
+// ctx 被取消或超過期限時，不再派出新的工作，等進行中的抓取中斷後回傳已完成的頁面與 ctx.Err()；
+// 被中斷的抓取不算頁面也不算錯誤。回傳時所有 worker 都已結束
+func (c *Crawler) Collect(ctx context.Context, url string, depth int) (CrawlResult, error) {
+	var r CrawlResult
+	start := time.Now()
+	err := c.walk(ctx, url, depth, func(p Page) {
+		r.Pages = append(r.Pages, p)
+		if p.Err != nil {
+			r.Summary.Errors++
+		} else {
+			r.Summary.Pages++
+		}
+	})
+	r.Summary.Elapsed = time.Since(start)
+	return r, err
+}
+
+// Stream 在背景爬取，每抓完一頁就從回傳的 channel 送出，爬完或被取消後關閉 channel
+// 呼叫者要讀到 channel 關閉為止，或是取消 ctx；否則爬取會一直停在送出頁面的地方
+func (c *Crawler) Stream(ctx context.Context, url string, depth int) <-chan Page {
+	pages := make(chan Page)
+	go func() {
+		defer close(pages)
+		c.walk(ctx, url, depth, func(p Page) {
+			select {
+			case pages <- p:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return pages
+}
+
+// walk 是 Crawler 的調度者，每抓完一頁就在呼叫者的 goroutine 中呼叫 emit
+func (c *Crawler) walk(ctx context.Context, url string, depth int, emit func(Page)) error {
+	if depth <= 0 {
+		return ctx.Err()
+	}
+	workers := c.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+
+	fetcher := c.Fetcher
+	if c.Politeness != (Politeness{}) {
+		fetcher = NewPoliteFetcher(fetcher, c.Politeness)
+	}
+
+	tasks := make(chan crawlTask)
+	results := make(chan Page)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for t := range tasks {
+				body, urls, err := fetch(ctx, fetcher, t.url)
+				results <- Page{URL: t.url, Body: body, Depth: t.depth, Parent: t.parent, Links: urls, Err: err}
+			}
+		})
+	}
+	// 結束前關閉 tasks 讓 worker 離開並等它們結束；
+	// 此時已沒有進行中的抓取，不會有 worker 卡在送出結果
+	defer func() {
+		close(tasks)
+		wg.Wait()
+	}()
+
+	frontier := []crawlTask{{url: url}}
+	seen := map[string]bool{url: true}
+	inFlight := 0
+	done := ctx.Done()
+	for len(frontier) > 0 || inFlight > 0 {
+		// 佇列是空的時候 send 為 nil，select 就只會等結果
+		var send chan crawlTask
+		var next crawlTask
+		if len(frontier) > 0 && ctx.Err() == nil {
+			send, next = tasks, frontier[0]
+		}
+
+		select {
+		case send <- next:
+			frontier = frontier[1:]
+			inFlight++
+		case p := <-results:
+			inFlight--
+			if ctx.Err() != nil {
+				continue // 被取消的抓取不是頁面的錯誤
+			}
+			emit(p)
+			if p.Err != nil || p.Depth+1 >= depth {
+				continue
+			}
+			for _, u := range p.Links {
+				if !seen[u] {
+					seen[u] = true
+					frontier = append(frontier, crawlTask{url: u, parent: p.URL, depth: p.Depth + 1})
+				}
+			}
+		case <-done:
+			// 丟掉還沒派出的工作，只等進行中的抓取結束
+			frontier, done = nil, nil
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,155 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCrawlerMatchesCrawl(t *testing.T) {
+	var out lockedBuffer
+	Crawl(&out, "https://golang.org/", 4, fetcher, newURLCache())
+	wantFound, wantNotFound := parseCrawl(out.String())
+
+	for _, workers := range []int{1, 2, 8} {
+		out.Reset()
+		c := &Crawler{Fetcher: fetcher, Workers: workers}
+		if err := c.Crawl(context.Background(), &out, "https://golang.org/", 4); err != nil {
+			t.Fatalf("%d workers: %v", workers, err)
+		}
+		found, notFound := parseCrawl(out.String())
+		if !maps.Equal(found, wantFound) || notFound != wantNotFound {
+			t.Errorf("%d workers found %v and %d missing pages, Crawl found %v and %d",
+				workers, found, notFound, wantFound, wantNotFound)
+		}
+	}
+}
+
+func TestCrawlerWorkers(t *testing.T) {
+	// 同時進行的抓取不能超過 Workers
+	wide := &wideFetcher{pages: 50}
+	res, err := (&Crawler{Fetcher: wide, Workers: 3}).Collect(context.Background(), "https://golang.org/", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Pages) != 51 {
+		t.Errorf("found %d pages of the wide site, want 51", len(res.Pages))
+	}
+	if n := wide.max.Load(); n > 3 {
+		t.Errorf("%d fetches ran at once with 3 workers", n)
+	}
+}
+
+func TestCrawlerDeadline(t *testing.T) {
+	// 超過期限時，Crawler 也要盡快回傳，不留下 goroutine
+	slow := &blockingFetcher{}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := (&Crawler{Fetcher: slow}).Crawl(ctx, io.Discard, "https://golang.org/", 4)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Crawl = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("Crawl took %v after the deadline", d)
+	}
+	if n := slow.running.Load(); n != 0 {
+		t.Errorf("%d fetches still running", n)
+	}
+}
+
+func TestCrawlerCollect(t *testing.T) {
+	res, err := (&Crawler{Fetcher: fetcher}).Collect(context.Background(), "https://golang.org/", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Summary.Pages != 4 || res.Summary.Errors != 1 {
+		t.Errorf("summary: %d pages, %d errors, want 4 pages, 1 error", res.Summary.Pages, res.Summary.Errors)
+	}
+	pages := make(map[string]Page)
+	for _, p := range res.Pages {
+		pages[p.URL] = p
+	}
+	tests := []struct {
+		url    string
+		depth  int
+		parent string
+		err    string
+	}{
+		{"https://golang.org/", 0, "", ""},
+		{"https://golang.org/pkg/", 1, "https://golang.org/", ""},
+		{"https://golang.org/pkg/fmt/", 2, "https://golang.org/pkg/", ""},
+		{"https://golang.org/cmd/", 1, "https://golang.org/", "not found: https://golang.org/cmd/"},
+	}
+	for _, tt := range tests {
+		p, ok := pages[tt.url]
+		if !ok {
+			t.Errorf("%s not collected", tt.url)
+			continue
+		}
+		if p.Depth != tt.depth || p.Parent != tt.parent {
+			t.Errorf("%s: depth %d, parent %q, want %d, %q", tt.url, p.Depth, p.Parent, tt.depth, tt.parent)
+		}
+		var err string
+		if p.Err != nil {
+			err = p.Err.Error()
+		}
+		if err != tt.err {
+			t.Errorf("%s: error %q, want %q", tt.url, err, tt.err)
+		}
+	}
+	if root := pages["https://golang.org/"]; !slices.Equal(root.Links, fetcher["https://golang.org/"].urls) {
+		t.Errorf("links of the start page = %q, want %q", root.Links, fetcher["https://golang.org/"].urls)
+	}
+}
+
+func TestCrawlerStream(t *testing.T) {
+	var urls []string
+	for p := range (&Crawler{Fetcher: fetcher}).Stream(context.Background(), "https://golang.org/", 4) {
+		urls = append(urls, p.URL)
+	}
+	slices.Sort(urls)
+	want := []string{
+		"https://golang.org/",
+		"https://golang.org/cmd/",
+		"https://golang.org/pkg/",
+		"https://golang.org/pkg/fmt/",
+		"https://golang.org/pkg/os/",
+	}
+	if !slices.Equal(urls, want) {
+		t.Errorf("streamed %q, want %q", urls, want)
+	}
+}
+
+// wideFetcher 的首頁連到 pages 個頁面，每次抓取都花一點時間，
+// 並記錄同時進行的抓取數的最大值
+type wideFetcher struct {
+	pages   int
+	running atomic.Int32
+	max     atomic.Int32
+}
+
+func (f *wideFetcher) Fetch(url string) (string, []string, error) {
+	n := f.running.Add(1)
+	defer f.running.Add(-1)
+	for {
+		m := f.max.Load()
+		if n <= m || f.max.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+	if url != "https://golang.org/" {
+		return "Page", nil, nil
+	}
+	urls := make([]string, f.pages)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("%s%d/", url, i)
+	}
+	return "Home", urls, nil
+}
@@ -0,0 +1,150 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// 真正透過 HTTP 抓取網頁的 Fetcher，讓練習的 Crawl 不只能跑在 fakeFetcher 上
+//
+// Fetch 回傳的 body 是頁面的 <title>，urls 是頁面中所有 <a href> 連結，
+// 相對網址會依頁面的網址（或 <base href>）轉成絕對網址
+
+// maxPageSize 是讀取單一頁面的上限，超過的部分直接忽略
+const maxPageSize = 1 << 20
+
+// ErrNotHTML 表示網址的內容不是 HTML（圖片、PDF 等），沒有標題也沒有連結可以爬
+// 錯誤會包住它，可以用 errors.Is 分辨這種頁面與真的空白頁面
+var ErrNotHTML = errors.New("not an HTML page")
+
+// httpFetcher 用 net/http 實作 Fetcher 與 ContextFetcher
+type httpFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher 回傳用 client 實際抓取網頁的 Fetcher；client 為 nil 時用 http.DefaultClient
+// 回傳值也實作 ContextFetcher，所以 CrawlContext 與 Crawler 能中斷進行中的請求：
+//
+//	CrawlContext(ctx, os.Stdout, "https://go.dev/", 2, NewHTTPFetcher(nil), newURLCache())
+func NewHTTPFetcher(client *http.Client) ContextFetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpFetcher{client: client}
+}
+
+// Fetch 抓取 rawURL，回傳頁面標題與頁面上的連結
+func (f *httpFetcher) Fetch(rawURL string) (string, []string, error) {
+	return f.FetchContext(context.Background(), rawURL)
+}
+
+// FetchContext 與 Fetch 相同，但 ctx 結束時會中斷請求與讀取
+// 404 的錯誤訊息與 fakeFetcher 相同（"not found: ..."），其他狀態碼則附上狀態
+// 不是 HTML 的內容（圖片、PDF 等）回傳包住 ErrNotHTML 的錯誤
+func (f *httpFetcher) FetchContext(ctx context.Context, rawURL string) (string, []string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", nil, fmt.Errorf("not found: %s", rawURL)
+	case resp.StatusCode != http.StatusOK:
+		return "", nil, fmt.Errorf("%s: %s", rawURL, resp.Status)
+	}
+	if mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mt != "text/html" {
+		return "", nil, fmt.Errorf("%s: %w (%s)", rawURL, ErrNotHTML, resp.Header.Get("Content-Type"))
+	}
+
+	doc, err := html.Parse(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %v", rawURL, err)
+	}
+	// 被轉址時，相對連結要以最後抵達的網址為準
+	title, urls := parsePage(doc, resp.Request.URL)
+	return title, urls, nil
+}
+
+// parsePage 走訪整棵 HTML 樹，取出第一個 <title> 的文字與所有 <a href> 連結
+// 連結依出現順序排列並去除重複；片段（#...）會被拿掉，只保留 http 與 https 連結
+func parsePage(doc *html.Node, base *url.URL) (title string, urls []string) {
+	// <base href> 會改變相對連結的基準，它必須出現在所有連結之前
+	for n := range doc.Descendants() {
+		if n.Type == html.ElementNode && n.Data == "base" {
+			if u, err := base.Parse(attr(n, "href")); err == nil {
+				base = u
+			}
+			break
+		}
+	}
+
+	seen := make(map[string]bool)
+	titleFound := false
+	for n := range doc.Descendants() {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		switch n.Data {
+		case "title":
+			if !titleFound {
+				title, titleFound = strings.Join(strings.Fields(text(n)), " "), true
+			}
+		case "a":
+			href, ok := hasAttr(n, "href")
+			if !ok {
+				continue
+			}
+			u, err := base.Parse(strings.TrimSpace(href))
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				continue // mailto:、javascript: 或寫錯的網址
+			}
+			u.Fragment, u.RawFragment = "", ""
+			if s := u.String(); !seen[s] {
+				seen[s] = true
+				urls = append(urls, s)
+			}
+		}
+	}
+	return title, urls
+}
+
+// attr 回傳元素的屬性值，沒有這個屬性時回傳空字串
+func attr(n *html.Node, key string) string {
+	v, _ := hasAttr(n, key)
+	return v
+}
+
+// hasAttr 回傳元素的屬性值以及元素是否有這個屬性
+func hasAttr(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Namespace == "" && a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// text 串接節點底下所有文字節點的內容
+func text(n *html.Node) string {
+	var b strings.Builder
+	for d := range n.Descendants() {
+		if d.Type == html.TextNode {
+			b.WriteString(d.Data)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package concurrency
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHTTPFetcher(t *testing.T) {
+	ts := httptest.NewServer(http.FileServerFS(fixtureSite))
+	defer ts.Close()
+	hf := NewHTTPFetcher(ts.Client())
+
+	title, urls, err := hf.Fetch(ts.URL + "/pkg/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != "Packages" {
+		t.Errorf("title of /pkg/ = %q, want %q", title, "Packages")
+	}
+	want := []string{
+		ts.URL + "/",
+		ts.URL + "/cmd/",
+		ts.URL + "/pkg/fmt/",
+		ts.URL + "/pkg/os/",
+	}
+	if !slices.Equal(urls, want) {
+		t.Errorf("links of /pkg/ = %q, want %q", urls, want)
+	}
+
+	_, _, err = hf.Fetch(ts.URL + "/cmd/")
+	if want := "not found: " + ts.URL + "/cmd/"; err == nil || err.Error() != want {
+		t.Errorf("Fetch(/cmd/) error = %v, want %q", err, want)
+	}
+
+	// 不是 HTML 的內容要與空白頁面分得開
+	if _, _, err = hf.Fetch(ts.URL + "/robots.txt"); !errors.Is(err, ErrNotHTML) {
+		t.Errorf("Fetch(/robots.txt) error = %v, want %v", err, ErrNotHTML)
+	}
+}
+
+func TestCrawlHTTP(t *testing.T) {
+	ts := httptest.NewServer(http.FileServerFS(fixtureSite))
+	defer ts.Close()
+
+	var out lockedBuffer
+	Crawl(&out, ts.URL+"/", 4, NewHTTPFetcher(ts.Client()), newURLCache())
+	found, notFound := parseCrawl(out.String())
+	for _, page := range []string{"/", "/pkg/", "/pkg/fmt/", "/pkg/os/"} {
+		if n := found[ts.URL+page]; n != 1 {
+			t.Errorf("%s fetched %d times, want 1", page, n)
+		}
+	}
+	if len(found) != 4 {
+		t.Errorf("found %d pages, want 4", len(found))
+	}
+	if notFound != 1 {
+		t.Errorf("%d missing pages reported, want 1", notFound)
+	}
+}
+
+// lockedBuffer 是可以讓多個 goroutine 同時寫入的 bytes.Buffer，遞迴的 Crawl 會從各個 goroutine 輸出
+type lockedBuffer struct {
+	mu sync.Mutex
+	bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.Buffer.Write(p)
+}
+
+// parseCrawl 從 Crawl 的輸出數出每個網址被找到幾次，以及回報了幾個找不到的頁面
+func parseCrawl(out string) (found map[string]int, notFound int) {
+	found = make(map[string]int)
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if rest, ok := strings.CutPrefix(line, "found: "); ok {
+			url, _, _ := strings.Cut(rest, " ")
+			found[url]++
+		} else if strings.HasPrefix(line, "not found: ") {
+			notFound++
+		}
+	}
+	return found, notFound
+}
+
+// fixtureSite 是與 fetcher 結構相同的小網站，供 httptest.Server 提供給 NewHTTPFetcher 爬取
+// 連結刻意混用相對路徑、絕對路徑、片段與非 HTTP 連結，/cmd/ 則不存在（404）
+// robots.txt 不是 HTML，也沒有頁面連到它
+var fixtureSite = fstest.MapFS{
+	"robots.txt": {Data: []byte("User-agent: *\n")},
+	"index.html": {Data: []byte(`<!DOCTYPE html>
+<html>
+<head><title>The Go Programming Language</title></head>
+<body>
+<a href="pkg/">Packages</a>
+<a href="/cmd/">Commands</a>
+<a href="#top">Top</a>
+<a href="mailto:golang-nuts@googlegroups.com">Mailing list</a>
+</body>
+</html>
+`)},
+	"pkg/index.html": {Data: []byte(`<!DOCTYPE html>
+<html>
+<head><title>
+	Packages
+</title></head>
+<body>
+<a href="../">Home</a>
+<a href="../cmd/">Commands</a>
+<a href="fmt/">fmt</a>
+<a href="./os/#pkg-overview">os</a>
+<a href="fmt/">fmt, again</a>
+</body>
+</html>
+`)},
+	"pkg/fmt/index.html": {Data: []byte(`<!DOCTYPE html>
+<html>
+<head><title>Package fmt</title><base href="/pkg/"></head>
+<body>
+<a href="/">Home</a>
+<a href=".">Packages</a>
+</body>
+</html>
+`)},
+	"pkg/os/index.html": {Data: []byte(`<!DOCTYPE html>
+<html>
+<head><title>Package os</title></head>
+<body>
+<a href="/">Home</a>
+<a href="..">Packages</a>
+</body>
+</html>
+`)},
+}
@@ -0,0 +1,137 @@
+package concurrency
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// 對每個主機保持禮貌的 Fetcher：限制請求速率（令牌桶）、同時進行的請求數，以及兩個請求之間的間隔
+//
+// 限制做在 Fetcher 這一層，所以不論 Crawl 開出多少 goroutine、Crawler 有多少 worker，
+// 同一個主機收到的請求都不會超過限制；不同主機各自計算，互不影響
+
+// Politeness 設定對每個主機的限制，零值表示不限制
+type Politeness struct {
+	Rate     float64       // 每秒最多幾個請求，<= 0 時不限速
+	Burst    int           // 令牌桶的容量，也就是閒置後最多能連續送出幾個請求，<= 0 時為 1
+	MaxConns int           // 同時進行的請求上限，<= 0 時不限
+	Delay    time.Duration // 兩個請求開始的最短間隔
+}
+
+// politeFetcher 依 Politeness 限制對 next 的呼叫
+type politeFetcher struct {
+	next  Fetcher
+	rules Politeness
+	now   func() time.Time // 時鐘，測試時可以換掉
+
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+// hostLimiter 是一個主機的令牌桶與同時請求數
+type hostLimiter struct {
+	conns     chan struct{} // MaxConns 個空位的 semaphore，不限時為 nil
+	tokens    float64
+	refilled  time.Time // tokens 最後一次補充的時間
+	lastStart time.Time // 上一個請求開始的時間
+}
+
+// NewPoliteFetcher 回傳依 rules 限制 next 的 Fetcher，讓遞迴的 Crawl、CrawlContext 也能遵守限制：
+//
+//	CrawlContext(ctx, w, url, depth, NewPoliteFetcher(f, Politeness{Rate: 2, MaxConns: 1}), cache)
+//
+// next 實作 ContextFetcher 時，等待中與進行中的抓取都能被取消
+func NewPoliteFetcher(next Fetcher, rules Politeness) ContextFetcher {
+	if rules.Burst <= 0 {
+		rules.Burst = 1
+	}
+	return &politeFetcher{next: next, rules: rules, now: time.Now, hosts: make(map[string]*hostLimiter)}
+}
+
+func (f *politeFetcher) Fetch(rawURL string) (string, []string, error) {
+	return f.FetchContext(context.Background(), rawURL)
+}
+
+// FetchContext 等到 rawURL 的主機允許時才抓取；等待期間 ctx 結束就回傳 ctx.Err()
+func (f *politeFetcher) FetchContext(ctx context.Context, rawURL string) (string, []string, error) {
+	h := f.limiter(rawURL)
+	if h.conns != nil {
+		select {
+		case h.conns <- struct{}{}:
+			defer func() { <-h.conns }()
+		case <-ctx.Done():
+			return "", nil, ctx.Err()
+		}
+	}
+	if err := f.wait(ctx, h); err != nil {
+		return "", nil, err
+	}
+	return fetch(ctx, f.next, rawURL)
+}
+
+// limiter 回傳 rawURL 的主機的 hostLimiter，第一次遇到時建立
+// 無法解析的網址都算在主機 "" 底下
+func (f *politeFetcher) limiter(rawURL string) *hostLimiter {
+	host := ""
+	if u, err := url.Parse(rawURL); err == nil {
+		host = u.Host
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	h, ok := f.hosts[host]
+	if !ok {
+		h = &hostLimiter{tokens: float64(f.rules.Burst), refilled: f.now()}
+		if f.rules.MaxConns > 0 {
+			h.conns = make(chan struct{}, f.rules.MaxConns)
+		}
+		f.hosts[host] = h
+	}
+	return h
+}
+
+// wait 等到令牌桶有令牌、而且距離上一個請求開始已超過 Delay，然後取走一個令牌
+func (f *politeFetcher) wait(ctx context.Context, h *hostLimiter) error {
+	for {
+		d := f.reserve(h)
+		if d <= 0 {
+			return nil
+		}
+		t := time.NewTimer(d)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve 在可以送出請求時取走令牌並回傳 0，否則回傳還要等多久
+// 等完之後要再呼叫一次，因為其他 goroutine 可能先取走了令牌
+func (f *politeFetcher) reserve(h *hostLimiter) time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.now()
+
+	var wait time.Duration
+	if f.rules.Rate > 0 {
+		h.tokens = min(h.tokens+now.Sub(h.refilled).Seconds()*f.rules.Rate, float64(f.rules.Burst))
+		h.refilled = now
+		if h.tokens < 1 {
+			wait = max(time.Duration((1-h.tokens)/f.rules.Rate*float64(time.Second)), 1)
+		}
+	}
+	if next := h.lastStart.Add(f.rules.Delay); now.Before(next) {
+		wait = max(wait, next.Sub(now))
+	}
+	if wait > 0 {
+		return wait
+	}
+	if f.rules.Rate > 0 {
+		h.tokens--
+	}
+	h.lastStart = now
+	return 0
+}
@@ -0,0 +1,115 @@
+package concurrency
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+)
+
+// fakeClock 是只在呼叫 advance 時才前進的時鐘
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// newTestPoliteFetcher 回傳使用 clock 的 politeFetcher
+func newTestPoliteFetcher(rules Politeness) (*politeFetcher, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	f := NewPoliteFetcher(fetcher, rules).(*politeFetcher)
+	f.now = clock.now
+	return f, clock
+}
+
+func TestReserveRate(t *testing.T) {
+	f, clock := newTestPoliteFetcher(Politeness{Rate: 10, Burst: 2})
+	h := f.limiter("https://golang.org/")
+
+	// 桶子一開始是滿的，可以連續送出 Burst 個請求
+	for i := range 2 {
+		if d := f.reserve(h); d != 0 {
+			t.Fatalf("request %d waits %v, want 0", i+1, d)
+		}
+	}
+	if d := f.reserve(h); d != 100*time.Millisecond {
+		t.Errorf("request with an empty bucket waits %v, want 100ms", d)
+	}
+	clock.advance(40 * time.Millisecond)
+	if d := f.reserve(h); d != 60*time.Millisecond {
+		t.Errorf("request 40ms later waits %v, want 60ms", d)
+	}
+	clock.advance(60 * time.Millisecond)
+	if d := f.reserve(h); d != 0 {
+		t.Errorf("request after a refill waits %v, want 0", d)
+	}
+
+	// 閒置再久，桶子裡也不會超過 Burst 個令牌
+	clock.advance(time.Hour)
+	for i := range 2 {
+		if d := f.reserve(h); d != 0 {
+			t.Fatalf("request %d after an hour waits %v, want 0", i+1, d)
+		}
+	}
+	if d := f.reserve(h); d <= 0 {
+		t.Errorf("request beyond the burst waits %v, want > 0", d)
+	}
+}
+
+func TestReserveDelay(t *testing.T) {
+	f, clock := newTestPoliteFetcher(Politeness{Delay: 30 * time.Millisecond})
+	h := f.limiter("https://golang.org/")
+
+	if d := f.reserve(h); d != 0 {
+		t.Fatalf("first request waits %v, want 0", d)
+	}
+	if d := f.reserve(h); d != 30*time.Millisecond {
+		t.Errorf("second request waits %v, want 30ms", d)
+	}
+	clock.advance(10 * time.Millisecond)
+	if d := f.reserve(h); d != 20*time.Millisecond {
+		t.Errorf("second request 10ms later waits %v, want 20ms", d)
+	}
+	clock.advance(20 * time.Millisecond)
+	if d := f.reserve(h); d != 0 {
+		t.Errorf("second request 30ms later waits %v, want 0", d)
+	}
+}
+
+func TestReservePerHost(t *testing.T) {
+	f, _ := newTestPoliteFetcher(Politeness{Rate: 1, Delay: time.Second})
+	a := f.limiter("https://golang.org/pkg/")
+	if b := f.limiter("https://golang.org/cmd/"); b != a {
+		t.Errorf("pages of one host have different limiters")
+	}
+	if d := f.reserve(a); d != 0 {
+		t.Fatalf("first request to golang.org waits %v, want 0", d)
+	}
+	if d := f.reserve(f.limiter("https://go.dev/")); d != 0 {
+		t.Errorf("first request to go.dev waits %v, want 0", d)
+	}
+	if d := f.reserve(a); d <= 0 {
+		t.Errorf("second request to golang.org waits %v, want > 0", d)
+	}
+}
+
+func TestPoliteMaxConns(t *testing.T) {
+	// 不論遞迴的 Crawl 開出多少 goroutine，同一個主機同時只有 MaxConns 個請求
+	wide := &wideFetcher{pages: 50}
+	Crawl(io.Discard, "https://golang.org/", 2, NewPoliteFetcher(wide, Politeness{MaxConns: 2}), newURLCache())
+	if n := wide.max.Load(); n > 2 {
+		t.Errorf("%d fetches ran at once with MaxConns 2", n)
+	}
+}
+
+func TestPoliteCancelWhileWaiting(t *testing.T) {
+	// 每 10 秒一個請求：第一頁之後都在等令牌，期限一到就要回傳
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := CrawlContext(ctx, io.Discard, "https://golang.org/", 4, NewPoliteFetcher(fetcher, Politeness{Rate: 0.1}), newURLCache())
+	if err != context.DeadlineExceeded {
+		t.Errorf("CrawlContext = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
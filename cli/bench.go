@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"first-golang/registry"
+	"first-golang/report"
+	"first-golang/runner"
+)
+
+var (
+	benchTime    string
+	benchTimeout time.Duration
+	benchFormat  string
+)
+
+var benchCmd = &command{
+	name:    "bench",
+	args:    "[-benchtime t] [-timeout d] [-format f] [pattern...]",
+	summary: "benchmark the exercise solutions and generic code",
+	help: `
+Bench runs the benchmarks of every lesson matched by the patterns (all
+lessons with benchmarks by default) with 'go test -bench -benchmem', and
+prints a table with the number of iterations, the time, the bytes and the
+allocations per iteration of each. The benchmarks exercise your own
+solutions, so changing an exercise changes its numbers.
+
+The benchmarks are ordinary go test benchmarks in each chapter's
+bench_test.go, named after the lesson's Run function: BenchmarkMoreTypes18
+for RunMoreTypes18, with one sub-benchmark per piece of code timed.
+'go test -bench . ./04-more-types' runs them directly.
+
+-benchtime is how long each benchmark runs, a duration such as 1s or a
+number of iterations such as 100x, and -timeout bounds each chapter's go
+test run, as with go test.
+
+-format json, yaml or tsv prints one record per benchmark instead.
+
+The exit status is non-zero when a benchmark fails, or a chapter's
+benchmarks do not build, panic or do not finish within the timeout.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.StringVar(&benchTime, "benchtime", "1s", "run each benchmark for this long, or this many times with an x suffix (100x)")
+		fs.DurationVar(&benchTimeout, "timeout", 10*time.Minute, "give up on a chapter's benchmarks after this long (0 for no limit)")
+		formatFlag(fs, &benchFormat)
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if err := report.CheckFormat(benchFormat); err != nil {
+			return &usageError{err.Error()}
+		}
+		if !validBenchTime(benchTime) {
+			return usagef("invalid -benchtime %q (want a duration such as 1s or a count such as 100x)", benchTime)
+		}
+		lessons := a.lessons
+		if len(args) > 0 {
+			var err error
+			if lessons, err = a.selectLessons(args); err != nil {
+				return err
+			}
+		}
+		var benched []registry.Lesson
+		for _, l := range lessons {
+			if runner.HasBenchmark(l) {
+				benched = append(benched, l)
+			}
+		}
+		if len(benched) == 0 {
+			return notFoundf("no benchmarks among the selected lessons")
+		}
+		goCmd, err := exec.LookPath("go")
+		if err != nil {
+			return errors.New("bench needs the go command to run the benchmarks")
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		results, benchErr := runner.Bench(ctx, goCmd, benched, runner.BenchOptions{
+			BenchTime: benchTime,
+			Timeout:   benchTimeout,
+		})
+
+		records := make([]report.Bench, len(results))
+		failed := 0
+		for i, r := range results {
+			records[i] = report.NewBench(r)
+			if r.Err != nil {
+				failed++
+			}
+		}
+		if benchFormat == "text" && len(results) > 0 {
+			tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+			fmt.Fprintln(tw, "CODE\tBENCHMARK\tN\tns/op\tB/op\tallocs/op\t")
+			for _, r := range results {
+				if r.Err != nil {
+					fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\t-\t\n", r.Lesson.Code, r.Name)
+					continue
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%d\t%.1f\t%d\t%d\t\n",
+					r.Lesson.Code, r.Name, r.N, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+			}
+			tw.Flush()
+			if failed > 0 {
+				fmt.Fprintln(a.stdout)
+			}
+			for _, r := range records {
+				if r.Error != "" {
+					fmt.Fprintf(a.stdout, "%s %s: %s\n", r.Code, r.Benchmark, r.Error)
+				}
+			}
+		} else if benchFormat != "text" {
+			if err := report.Write(a.stdout, benchFormat, records); err != nil {
+				return err
+			}
+		}
+		if benchErr != nil {
+			return benchErr
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d benchmarks failed", failed, len(results))
+		}
+		return nil
+	},
+}
+
+// validBenchTime reports whether s is a valid go test -benchtime: a
+// positive duration, or a positive count followed by x.
+func validBenchTime(s string) bool {
+	if n, ok := strings.CutSuffix(s, "x"); ok {
+		i, err := strconv.Atoi(n)
+		return err == nil && i > 0
+	}
+	d, err := time.ParseDuration(s)
+	return err == nil && d > 0
+}
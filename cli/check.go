@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"first-golang/progress"
+	"first-golang/registry"
+	"first-golang/runner"
+)
+
+var (
+	checkVerbose bool
+	checkTimeout time.Duration
+)
+
+var checkCmd = &command{
+	name:    "check",
+	args:    "[-v] [-timeout d] [-state file] [pattern...]",
+	summary: "validate exercise solutions and print a scoreboard",
+	help: `
+Check runs the checker of every exercise matched by the patterns (all
+exercises by default) without running the lessons themselves, and prints
+a scoreboard: each exercise with how many of its cases passed, the
+failing cases with what the solution returned and what was expected, and
+the totals. The verdicts are recorded in the progress file.
+
+The exit status is non-zero when an exercise fails.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.BoolVar(&checkVerbose, "v", false, "list the passing cases too")
+		fs.DurationVar(&checkTimeout, "timeout", runner.DefaultTimeout, "give up on a checker after this long (0 for no limit)")
+		stateFlag(fs)
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		lessons := a.lessons
+		if len(args) > 0 {
+			var err error
+			if lessons, err = a.selectLessons(args); err != nil {
+				return err
+			}
+		}
+		var exercises []registry.Lesson
+		for _, l := range lessons {
+			if l.IsExercise() {
+				exercises = append(exercises, l)
+			}
+		}
+		if len(exercises) == 0 {
+			return notFoundf("no exercises among the selected lessons")
+		}
+
+		st, err := progress.Load(statePath)
+		if err != nil {
+			fmt.Fprintf(a.stderr, "warning: %v; progress not recorded\n", err)
+		}
+		opts := runner.Options{Timeout: checkTimeout}
+		failed, cases, casesPassed := 0, 0, 0
+		for _, l := range exercises {
+			v := runner.Check(l, opts)
+			if st != nil {
+				st.RecordCheck(l.Code, v.Err(), time.Now())
+			}
+			passed, total := v.Score()
+			cases += total
+			casesPassed += passed
+
+			status := "ok"
+			if !v.Passed() {
+				status = "FAIL"
+				failed++
+			}
+			fmt.Fprintf(a.stdout, "%-7s %s %-36s %2d/%-2d cases\n", status, l.Code, l.Title, passed, total)
+			for _, c := range v.Cases {
+				switch {
+				case !c.Passed:
+					fmt.Fprintf(a.stdout, "          ✗ %s\n", c)
+				case checkVerbose:
+					fmt.Fprintf(a.stdout, "          ✓ %s\n", c)
+				}
+			}
+		}
+		a.saveProgress(st)
+
+		fmt.Fprintf(a.stdout, "\nScore: %d/%d exercises passed, %d/%d cases\n",
+			len(exercises)-failed, len(exercises), casesPassed, cases)
+		if failed > 0 {
+			return fmt.Errorf("%d of %d exercises failed", failed, len(exercises))
+		}
+		return nil
+	},
+}
@@ -0,0 +1,291 @@
+// Package cli implements the command tree of the lesson runner (go run main.go).
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"first-golang/i18n"
+	"first-golang/registry"
+	"first-golang/runner"
+)
+
+// Exit codes returned by Main, one per error class.
+const (
+	ExitOK       = 0
+	ExitFailure  = 1 // the command ran but failed
+	ExitUsage    = 2 // bad command line
+	ExitNotFound = 3 // no lesson matched the given code or query
+)
+
+const program = "go run main.go"
+
+// lessonCode matches a lesson code such as 04-18.
+var lessonCode = regexp.MustCompile(`^\d{2}-\d{2}$`)
+
+// usageError reports a malformed command line.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+func usagef(format string, args ...any) error {
+	return &usageError{fmt.Sprintf(format, args...)}
+}
+
+// notFoundError reports that nothing matched a code or query.
+type notFoundError struct {
+	msg string
+}
+
+func (e *notFoundError) Error() string { return e.msg }
+
+func notFoundf(format string, args ...any) error {
+	return &notFoundError{fmt.Sprintf(format, args...)}
+}
+
+// exitCode maps an error returned by a command to its exit code.
+func exitCode(err error) int {
+	var ue *usageError
+	var nf *notFoundError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &ue):
+		return ExitUsage
+	case errors.As(err, &nf):
+		return ExitNotFound
+	default:
+		return ExitFailure
+	}
+}
+
+type command struct {
+	name    string
+	args    string // argument synopsis shown in usage
+	summary string
+	help    string
+	run     func(a *app, fs *flag.FlagSet, args []string) error
+	flags   func(fs *flag.FlagSet) // optional, registers the command's flags
+
+	// untilInterrupt is set for commands that only stop on Ctrl-C. The
+	// repl keeps the terminal in raw mode, where Ctrl-C is just a byte,
+	// so it does not offer them.
+	untilInterrupt bool
+}
+
+var commands []*command
+
+func init() {
+	commands = []*command{
+		runCmd,
+		listCmd,
+		showCmd,
+		searchCmd,
+		infoCmd,
+		goldenCmd,
+		checkCmd,
+		benchCmd,
+		hintCmd,
+		solutionCmd,
+		progressCmd,
+		doctorCmd,
+		newCmd,
+		watchCmd,
+		replCmd,
+		serveCmd,
+		helpCmd,
+	}
+}
+
+func lookupCommand(name string) *command {
+	for _, c := range commands {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// app carries what every command needs.
+type app struct {
+	lessons []registry.Lesson
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+func (a *app) lookup(code string) (registry.Lesson, error) {
+	for _, l := range a.lessons {
+		if l.Code == code {
+			return l, nil
+		}
+	}
+	return registry.Lesson{}, notFoundf("unknown lesson %q (see '%s list')", code, program)
+}
+
+// selectLessons resolves run patterns, mapping runner errors to the
+// matching error class.
+func (a *app) selectLessons(patterns []string) ([]registry.Lesson, error) {
+	lessons, err := runner.Select(a.lessons, patterns...)
+	switch {
+	case errors.Is(err, runner.ErrNoMatch):
+		return nil, &notFoundError{err.Error()}
+	case err != nil:
+		return nil, &usageError{err.Error()}
+	}
+	return lessons, nil
+}
+
+// Main runs the command line args (without the program name) against the
+// registered lessons and returns the process exit code.
+func Main(args []string) int {
+	a := &app{lessons: registry.All(), stdout: os.Stdout, stderr: os.Stderr}
+	return a.main(args)
+}
+
+func (a *app) main(args []string) int {
+	args, err := a.globalFlags(args)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		a.usage(a.stdout)
+		return ExitOK
+	case err != nil:
+		fmt.Fprintf(a.stderr, "%v\n", err)
+		fmt.Fprintf(a.stderr, "Run '%s help' for usage.\n", program)
+		return ExitUsage
+	case len(args) == 0:
+		a.usage(a.stderr)
+		return ExitUsage
+	}
+
+	name, rest := args[0], args[1:]
+
+	cmd := lookupCommand(name)
+	if cmd == nil {
+		// Keep the original "go run main.go 04-18" form working. Anything
+		// shaped like a lesson code goes to run as well, so that a code
+		// matching no lesson is reported as not found, as with 'run'.
+		if _, err := a.selectLessons(args[:1]); err == nil || lessonCode.MatchString(name) {
+			cmd, rest = runCmd, args
+		} else {
+			fmt.Fprintf(a.stderr, "Unknown command or lesson: %s\n", name)
+			fmt.Fprintf(a.stderr, "Run '%s help' for usage.\n", program)
+			return ExitUsage
+		}
+	}
+
+	err = a.exec(cmd, rest)
+	if err != nil {
+		fmt.Fprintf(a.stderr, "%s: %v\n", cmd.name, err)
+		var ue *usageError
+		if errors.As(err, &ue) {
+			fmt.Fprintf(a.stderr, "Run '%s help %s' for usage.\n", program, cmd.name)
+		}
+	}
+	return exitCode(err)
+}
+
+// globalFlags parses the flags that come before the command and apply to
+// every command, and returns the arguments after them. The language the
+// lessons narrate in is set here, from -lang or else the locale.
+func (a *app) globalFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet(program, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	lang := fs.String("lang", "", "")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	l := i18n.FromEnv()
+	if *lang != "" {
+		var err error
+		if l, err = i18n.Parse(*lang); err != nil {
+			return nil, err
+		}
+	}
+	i18n.Set(l)
+	return fs.Args(), nil
+}
+
+func (a *app) exec(cmd *command, args []string) error {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			a.commandUsage(a.stdout, cmd, fs)
+			return nil
+		}
+		return &usageError{err.Error()}
+	}
+	return cmd.run(a, fs, fs.Args())
+}
+
+func (a *app) usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s [-lang zh-TW|en] <command> [arguments]\n", program)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "-lang picks the language the lessons narrate in: zh-TW (the default)")
+	fmt.Fprintln(w, "or en. Without it, the language follows LC_ALL, LC_MESSAGES or LANG.")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "'%s <pattern>' is short for '%s run <pattern>'.\n", program, program)
+	fmt.Fprintf(w, "Run '%s help <command>' for details.\n", program)
+}
+
+func (a *app) commandUsage(w io.Writer, cmd *command, fs *flag.FlagSet) {
+	fmt.Fprintf(w, "Usage: %s %s", program, cmd.name)
+	if cmd.args != "" {
+		fmt.Fprintf(w, " %s", cmd.args)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, strings.TrimSpace(cmd.help))
+
+	hasFlags := false
+	fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+	if hasFlags {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Flags:")
+		fs.SetOutput(w)
+		fs.PrintDefaults()
+		fs.SetOutput(io.Discard)
+	}
+}
+
+var helpCmd = &command{
+	name:    "help",
+	args:    "[command]",
+	summary: "show help for a command",
+	help:    "Help prints the list of commands, or the usage of one command.",
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		switch len(args) {
+		case 0:
+			a.usage(a.stdout)
+			return nil
+		case 1:
+			cmd := lookupCommand(args[0])
+			if cmd == nil {
+				return usagef("unknown command %q", args[0])
+			}
+			fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+			if cmd.flags != nil {
+				cmd.flags(fs)
+			}
+			a.commandUsage(a.stdout, cmd, fs)
+			return nil
+		default:
+			return usagef("help takes at most one command")
+		}
+	},
+}
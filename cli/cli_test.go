@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"first-golang/registry"
+)
+
+func testApp() (*app, *bytes.Buffer) {
+	var out bytes.Buffer
+	lessons := []registry.Lesson{
+		{Code: "01-01", Title: "Hello", Run: func(w io.Writer) {}},
+		{Code: "01-02", Title: "Goodbye", Run: func(w io.Writer) {}},
+	}
+	return &app{lessons: lessons, stdout: &out, stderr: &out}, &out
+}
+
+func TestMainExitCodes(t *testing.T) {
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{[]string{"99-99"}, ExitNotFound},
+		{[]string{"run", "99-99"}, ExitNotFound},
+		{[]string{"bogus"}, ExitUsage},
+		{[]string{"list", "01"}, ExitOK},
+		{[]string{"list", "-format", "xml"}, ExitUsage},
+		{nil, ExitUsage},
+	}
+	for _, tt := range tests {
+		a, out := testApp()
+		if got := a.main(tt.args); got != tt.want {
+			t.Errorf("main(%q) = %d, want %d\n%s", tt.args, got, tt.want, out)
+		}
+	}
+}
@@ -0,0 +1,517 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"first-golang/doctor"
+	"first-golang/exercise"
+	"first-golang/golden"
+	"first-golang/highlight"
+	"first-golang/i18n"
+	"first-golang/registry"
+	"first-golang/report"
+	"first-golang/runner"
+	"first-golang/scaffold"
+	"first-golang/search"
+	"first-golang/tour"
+	"golang.org/x/term"
+)
+
+var (
+	runTimeout time.Duration
+	runFormat  string
+	listFormat string
+)
+
+// formatFlag registers -format, for the commands with structured output.
+func formatFlag(fs *flag.FlagSet, p *string) {
+	fs.StringVar(p, "format", "text", "output format: "+strings.Join(report.Formats, ", "))
+}
+
+var runCmd = &command{
+	name:    "run",
+	args:    "[-timeout d] [-format f] [-state file] <pattern>...",
+	summary: "run lessons by code, chapter, range or glob",
+	help: `
+Run executes the lessons matched by the patterns, in order. A pattern is
+a lesson code (04-18), a chapter (05), an inclusive range (04-10:04-18)
+or a glob (07-0*). For example:
+
+	go run main.go run 04-18
+	go run main.go run 05
+	go run main.go run 07-01:07-06 07-09
+
+Every lesson runs under a supervisor: a panic is recovered and its stack
+reported, and a lesson still running after -timeout is abandoned. Either
+way the next lesson still runs, and the exit status is non-zero.
+
+When more than one lesson runs, each is framed by a header and a footer
+with its status and elapsed time, and a summary is printed at the end.
+
+After an exercise finishes, its check validates the solution. Runs and
+check results are recorded in the progress file (see 'progress').
+
+-format json, yaml or tsv prints one record per lesson instead, with its
+code, title, source file, tour URL, status, duration in milliseconds,
+captured output and, for an exercise, the check result.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.DurationVar(&runTimeout, "timeout", runner.DefaultTimeout, "abandon a lesson after this long (0 for no limit)")
+		formatFlag(fs, &runFormat)
+		stateFlag(fs)
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) == 0 {
+			return usagef("missing lesson pattern")
+		}
+		if err := report.CheckFormat(runFormat); err != nil {
+			return &usageError{err.Error()}
+		}
+		lessons, err := a.selectLessons(args)
+		if err != nil {
+			return err
+		}
+		opts := runner.Options{Timeout: runTimeout}
+
+		var (
+			results []runner.Result
+			checks  map[string]exercise.Verdict
+		)
+		switch {
+		case runFormat != "text":
+			outputs := make([][]byte, len(lessons))
+			for i, l := range lessons {
+				r, out := runner.Capture(l, opts)
+				results = append(results, r)
+				outputs[i] = out
+			}
+			checks = a.checkAndRecord(nil, results, opts)
+			records := make([]report.Run, len(results))
+			for i, r := range results {
+				v, checked := checks[r.Lesson.Code]
+				records[i] = report.NewRun(r, outputs[i], checked, v.Err())
+			}
+			if err := report.Write(a.stdout, runFormat, records); err != nil {
+				return err
+			}
+		case len(lessons) == 1:
+			r := runner.Run(lessons[0], a.stdout, opts)
+			if r.Failed() {
+				runner.Report(a.stderr, r)
+			}
+			results = append(results, r)
+			checks = a.checkAndRecord(a.stderr, results, opts)
+		default:
+			results = runner.Batch(a.stdout, lessons, opts)
+			checks = a.checkAndRecord(a.stdout, results, opts)
+		}
+
+		failed := 0
+		for _, r := range results {
+			if r.Failed() {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d lessons did not finish normally", failed, len(results))
+		}
+		if n := failedChecks(checks); n > 0 {
+			return fmt.Errorf("%d of %d exercises failed their check", n, len(checks))
+		}
+		return nil
+	},
+}
+
+var listCmd = &command{
+	name:    "list",
+	args:    "[-format f] [prefix]",
+	summary: "list lessons, optionally only one chapter",
+	help: `
+List prints every lesson with its title and source file.
+A prefix such as "05" or "04-1" limits the list to matching codes.
+
+-format json, yaml or tsv prints one record per lesson instead, with its
+code, title, source file, tour URL and whether it is an exercise.`,
+	flags: func(fs *flag.FlagSet) {
+		formatFlag(fs, &listFormat)
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) > 1 {
+			return usagef("list takes at most one prefix")
+		}
+		if err := report.CheckFormat(listFormat); err != nil {
+			return &usageError{err.Error()}
+		}
+		prefix := ""
+		if len(args) == 1 {
+			prefix = args[0]
+		}
+		var records []report.Lesson
+		for _, l := range a.lessons {
+			if !strings.HasPrefix(l.Code, prefix) {
+				continue
+			}
+			if listFormat == "text" {
+				fmt.Fprintf(a.stdout, "  %s - %s (%s)\n", l.Code, l.Title, l.File)
+			}
+			records = append(records, report.NewLesson(l))
+		}
+		if listFormat != "text" {
+			if err := report.Write(a.stdout, listFormat, records); err != nil {
+				return err
+			}
+		}
+		if len(records) == 0 {
+			return notFoundf("no lessons match prefix %q", prefix)
+		}
+		return nil
+	},
+}
+
+var (
+	showComments string
+	showColor    string
+	showNumbers  bool
+	showTour     bool
+)
+
+var showCmd = &command{
+	name:    "show",
+	args:    "[-comments all|strip|only] [-color auto|always|never] [-n=false] [-tour] <code>",
+	summary: "print the source file of a lesson",
+	help: `
+Show prints the Go source file a lesson lives in, with line numbers and
+syntax highlighting. Colors are used only when stdout is a terminal and
+NO_COLOR is not set, unless -color says otherwise.
+
+-comments strip drops the Chinese study notes from the listing, and
+-comments only prints nothing but those notes, each with its line number.
+
+-tour prints the text of the lesson's tour page above the source, from
+the offline copy of the tour built into the program.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.StringVar(&showComments, "comments", "all", "which comments to print: all, strip (no Chinese notes) or only (just the notes)")
+		fs.StringVar(&showColor, "color", "auto", "highlight the source: auto, always or never")
+		fs.BoolVar(&showNumbers, "n", true, "number the lines")
+		fs.BoolVar(&showTour, "tour", false, "print the text of the tour page first")
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) != 1 {
+			return usagef("show takes exactly one lesson code")
+		}
+		mode, err := highlight.ParseCommentMode(showComments)
+		if err != nil {
+			return &usageError{err.Error()}
+		}
+		color, err := a.useColor(showColor)
+		if err != nil {
+			return err
+		}
+		l, err := a.lookup(args[0])
+		if err != nil {
+			return err
+		}
+		src, err := os.ReadFile(l.Path())
+		if errors.Is(err, fs.ErrNotExist) {
+			return notFoundf("source file %s of lesson %s does not exist", l.File, l.Code)
+		}
+		if err != nil {
+			return err
+		}
+		if showTour {
+			slide, ok := tour.LookupURL(l.TourURL)
+			if !ok {
+				return notFoundf("the offline tour has no page %s", l.TourURL)
+			}
+			fmt.Fprintf(a.stdout, "%s (%s)\n\n%s\n\n", slide.Title, l.TourURL, slide.Text)
+		}
+		return highlight.Render(a.stdout, src, highlight.Options{
+			LineNumbers: showNumbers,
+			Color:       color,
+			Comments:    mode,
+		})
+	},
+}
+
+// useColor resolves a -color flag value against the app's stdout.
+func (a *app) useColor(when string) (bool, error) {
+	switch when {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		f, ok := a.stdout.(*os.File)
+		return ok && term.IsTerminal(int(f.Fd())), nil
+	}
+	return false, usagef("unknown -color value %q (want auto, always or never)", when)
+}
+
+var (
+	searchMax   int
+	searchColor string
+)
+
+var searchCmd = &command{
+	name:    "search",
+	args:    "[-max n] [-color auto|always|never] <query>",
+	summary: "search lesson titles, identifiers and comments",
+	help: `
+Search looks for the query in every lesson's title and code and in the
+identifiers and comments of its source file, and prints the matching
+lessons best first, each with the lines that matched. Every word of the
+query must occur in the lesson; matching is case-insensitive and works
+for Chinese as well:
+
+	search close channel
+	search 互斥鎖`,
+	flags: func(fs *flag.FlagSet) {
+		fs.IntVar(&searchMax, "max", 10, "print at most this many lessons (0 for all)")
+		fs.StringVar(&searchColor, "color", "auto", "highlight the matches: auto, always or never")
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) == 0 {
+			return usagef("missing search query")
+		}
+		color, err := a.useColor(searchColor)
+		if err != nil {
+			return err
+		}
+		query := strings.Join(args, " ")
+		hits := search.Build(a.lessons).Search(query)
+		if len(hits) == 0 {
+			return notFoundf("no lessons match %q", query)
+		}
+		for i, h := range hits {
+			if searchMax > 0 && i == searchMax {
+				fmt.Fprintf(a.stdout, "... and %d more (use -max 0 to see all)\n", len(hits)-i)
+				break
+			}
+			fmt.Fprintf(a.stdout, "  %s - %s (score %d)\n", h.Lesson.Code, mark(h.Lesson.Title, search.Locate(h.Lesson.Title, query), color), h.Score)
+			for _, s := range h.Snippets {
+				fmt.Fprintf(a.stdout, "      %4d: %s\n", s.Line, mark(s.Text, s.Matches, color))
+			}
+		}
+		return nil
+	},
+}
+
+// mark highlights the given byte ranges of s in bold yellow, or returns s
+// unchanged when color is off.
+func mark(s string, ranges [][2]int, color bool) string {
+	if !color || len(ranges) == 0 {
+		return s
+	}
+	var sb strings.Builder
+	prev := 0
+	for _, r := range ranges {
+		sb.WriteString(s[prev:r[0]])
+		sb.WriteString("\x1b[1;33m")
+		sb.WriteString(s[r[0]:r[1]])
+		sb.WriteString("\x1b[0m")
+		prev = r[1]
+	}
+	sb.WriteString(s[prev:])
+	return sb.String()
+}
+
+var infoText bool
+
+var infoCmd = &command{
+	name:    "info",
+	args:    "[-text] <code>",
+	summary: "print the metadata of a lesson",
+	help: `
+Info prints the code, title, tour page and source file of a lesson, and
+the tour URL in the header of the source file when it differs from the
+registered one.
+
+With -text it also prints the text of the tour page, from the offline
+copy of the tour built into the program.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.BoolVar(&infoText, "text", false, "print the text of the tour page too")
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) != 1 {
+			return usagef("info takes exactly one lesson code")
+		}
+		l, err := a.lookup(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Code:   %s\n", l.Code)
+		fmt.Fprintf(a.stdout, "Title:  %s\n", l.Title)
+		fmt.Fprintf(a.stdout, "Tour:   %s\n", l.TourURL)
+		fmt.Fprintf(a.stdout, "File:   %s\n", l.File)
+		if src, err := os.ReadFile(l.Path()); err == nil {
+			switch url, ok := tour.Header(src); {
+			case !ok:
+				fmt.Fprintln(a.stdout, "Header: none")
+			case url != l.TourURL:
+				fmt.Fprintf(a.stdout, "Header: %s (differs from the registered tour page)\n", url)
+			}
+		}
+		slide, ok := tour.LookupURL(l.TourURL)
+		if !ok {
+			fmt.Fprintln(a.stdout, "Text:   not in the offline copy of the tour")
+			return nil
+		}
+		if infoText {
+			fmt.Fprintf(a.stdout, "\n%s\n\n%s\n", slide.Title, slide.Text)
+		}
+		return nil
+	},
+}
+
+var goldenUpdate bool
+
+var goldenCmd = &command{
+	name:    "golden",
+	args:    "[-update] [pattern...]",
+	summary: "compare lesson output with golden files",
+	help: `
+Golden runs the lessons matched by the patterns (all lessons by default),
+captures their output and compares it with testdata/golden/<code>.golden.
+Known nondeterminism such as timestamps, random numbers, map iteration
+order and goroutine scheduling is masked before comparing. The lessons
+always narrate in Chinese here, the language of the golden files.
+
+With -update the golden files are rewritten from the current output.
+'go test ./golden' makes the same comparison, with -update likewise.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.BoolVar(&goldenUpdate, "update", false, "rewrite the golden files instead of comparing")
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		lessons := a.lessons
+		if len(args) > 0 {
+			var err error
+			if lessons, err = a.selectLessons(args); err != nil {
+				return err
+			}
+		}
+		opts := runner.Options{Timeout: runner.DefaultTimeout}
+		// The golden files record the Chinese narration, whatever the
+		// user's language, which is restored afterwards for the repl.
+		defer i18n.Set(i18n.Current())
+		i18n.Set(i18n.ZhTW)
+
+		failed := 0
+		for _, l := range lessons {
+			o, err := golden.Check(l, opts, goldenUpdate)
+			if err != nil {
+				return fmt.Errorf("%s: %w", l.Code, err)
+			}
+			fmt.Fprintf(a.stdout, "%-7s %s %s", o.Status, l.Code, l.Title)
+			if o.Reason != "" {
+				fmt.Fprintf(a.stdout, " (%s)", o.Reason)
+			}
+			fmt.Fprintln(a.stdout)
+			if o.Status == golden.StatusMissing {
+				fmt.Fprintf(a.stdout, "        no %s; run with -update to create it\n", golden.Path(l))
+			}
+			if o.Diff != "" {
+				fmt.Fprint(a.stdout, o.Diff)
+			}
+			if o.Failed() {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d lessons do not match their golden files", failed, len(lessons))
+		}
+		return nil
+	},
+}
+
+var doctorCmd = &command{
+	name:    "doctor",
+	summary: "check lesson registrations against the source files",
+	help: `
+Doctor cross-checks the registered lessons against the files on disk and
+prints every inconsistency as file:line: message:
+
+	- a lesson whose source file does not exist, or whose code does not
+	  match its directory, file number or tour URL
+	- a '// https://go.dev/tour/...' header that is missing or names
+	  another page than the lesson
+	- an exported RunXxx function that is not registered
+	- a numbered file no lesson is registered from, or numbered for more
+	  pages than are registered from it (07&08)
+
+Files whose name does not start with a page number, such as helpers, and
+the 00-template.go files are skipped. The exit status is non-zero when
+there is a problem.`,
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) > 0 {
+			return usagef("doctor takes no arguments")
+		}
+		problems, err := doctor.Examine(registry.Root(), a.lessons)
+		if err != nil {
+			return err
+		}
+		for _, p := range problems {
+			fmt.Fprintln(a.stdout, p)
+		}
+		fmt.Fprintln(a.stdout, doctor.Summary(problems))
+		if len(problems) > 0 {
+			return errors.New("lessons and source files disagree")
+		}
+		return nil
+	},
+}
+
+var newCmd = &command{
+	name:    "new",
+	args:    "<chapter> <page> <title> <tour-url>",
+	summary: "create a lesson file from the chapter's template",
+	help: `
+New copies the chapter's 00-template.go into a new lesson file, named
+like the others from the page and title, with the tour URL as header,
+EmptyTemplate renamed to the chapter's next RunXxxNN function and an init
+function registering the lesson. For example:
+
+	go run main.go new 04 27 "Exercise: Fibonacci closure" https://go.dev/tour/moretypes/27
+
+creates 04-more-types/27-Exercise:Fibonacci-closure.go with RunMoreTypes27.
+The chapter can be given by number or directory name. The title may also
+be given unquoted, as every word between the page and the URL.`,
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) < 4 {
+			return usagef("new takes a chapter, a page, a title and a tour URL")
+		}
+		page, err := strconv.Atoi(args[1])
+		if err != nil || page < 1 || page > 99 {
+			return usagef("page %q is not a number from 1 to 99", args[1])
+		}
+		url := args[len(args)-1]
+		if !strings.HasPrefix(url, "https://go.dev/tour/") {
+			return usagef("tour URL %q does not start with https://go.dev/tour/", url)
+		}
+		title := strings.Join(args[2:len(args)-1], " ")
+		if strings.ContainsAny(title, `/\`) {
+			return usagef("title %q cannot be used in a file name", title)
+		}
+
+		res, err := scaffold.Create(registry.Root(), a.lessons, scaffold.Lesson{
+			Chapter: args[0],
+			Page:    page,
+			Title:   title,
+			TourURL: url,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Created %s with %s, registered as lesson %s.\n", res.File, res.RunFunc, res.Code)
+		fmt.Fprintf(a.stdout, "Run it with: %s run %s\n", program, res.Code)
+		return nil
+	},
+}
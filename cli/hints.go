@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"first-golang/diff"
+	"first-golang/progress"
+	"first-golang/registry"
+	"first-golang/solutions"
+)
+
+var solutionFull bool
+
+var hintCmd = &command{
+	name:    "hint",
+	args:    "[-state file] <code> [n]",
+	summary: "reveal the hints of an exercise one at a time",
+	help: `
+Hint prints what an exercise asks for, taken from the comments at the top
+of its file, followed by its hints. Each exercise has a few hints, from a
+gentle nudge to nearly the answer. They are revealed one at a time: every
+'hint' shows one more than last time, and the progress file remembers how
+many you have seen. 'hint <code> n' shows the first n.
+
+When the hints run out, 'solution' compares your file with a reference
+solution.`,
+	flags: func(fs *flag.FlagSet) {
+		stateFlag(fs)
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) < 1 || len(args) > 2 {
+			return usagef("hint takes a lesson code and optionally the number of hints")
+		}
+		l, hints, err := a.lookupHints(args[0])
+		if err != nil {
+			return err
+		}
+
+		st, err := progress.Load(statePath)
+		if err != nil {
+			fmt.Fprintf(a.stderr, "warning: %v; hints seen not recorded\n", err)
+		}
+		n := 1
+		if st != nil {
+			if r, ok := st.Lessons[l.Code]; ok {
+				n = min(r.HintsSeen+1, len(hints))
+			}
+		}
+		if len(args) == 2 {
+			if n, err = strconv.Atoi(args[1]); err != nil || n < 1 || n > len(hints) {
+				return usagef("%s has hints 1 to %d, not %q", l.Code, len(hints), args[1])
+			}
+		}
+
+		fmt.Fprintf(a.stdout, "%s %s\n", l.Code, l.Title)
+		if src, err := os.ReadFile(l.Path()); err == nil {
+			if stmt := solutions.Statement(string(src)); stmt != "" {
+				fmt.Fprintf(a.stdout, "\n%s\n", indent(stmt, "  "))
+			}
+		}
+		for i, h := range hints[:n] {
+			fmt.Fprintf(a.stdout, "\nHint %d/%d:\n%s\n", i+1, len(hints), indent(h, "  "))
+		}
+		fmt.Fprintln(a.stdout)
+		if n < len(hints) {
+			fmt.Fprintf(a.stdout, "Stuck? '%s hint %s' shows the next hint.\n", program, l.Code)
+		} else {
+			fmt.Fprintf(a.stdout, "That was the last hint; '%s solution %s' compares your file with the reference.\n", program, l.Code)
+		}
+
+		if st != nil {
+			st.RecordHints(l.Code, n)
+			a.saveProgress(st)
+		}
+		return nil
+	},
+}
+
+var solutionCmd = &command{
+	name:    "solution",
+	args:    "[-full] [-state file] <code>",
+	summary: "compare an exercise with its reference solution",
+	help: `
+Solution prints a unified diff from your solution of an exercise to the
+reference solution. Only the declarations the exercise asks for, such as
+Sqrt or WordCount, are compared, wherever they are in your file: the
+lines starting with '-' are yours, those with '+' are the reference.
+Passing the check is what counts; a solution that differs from the
+reference can be just as right. With -full, the whole reference
+solution is printed instead.
+
+Consider 'hint' first: the solution gives everything away.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.BoolVar(&solutionFull, "full", false, "print the whole reference instead of a diff")
+		stateFlag(fs)
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) != 1 {
+			return usagef("solution takes exactly one lesson code")
+		}
+		l, _, err := a.lookupHints(args[0])
+		if err != nil {
+			return err
+		}
+		ref, ok := solutions.Reference(l.Code)
+		if !ok {
+			return notFoundf("%s has no reference solution", l.Code)
+		}
+
+		if solutionFull {
+			fmt.Fprint(a.stdout, ref)
+		} else {
+			src, err := os.ReadFile(l.Path())
+			if err != nil {
+				return err
+			}
+			mine, err := solutions.Extract(string(src), ref)
+			if err != nil {
+				return fmt.Errorf("%s: %w", l.File, err)
+			}
+			if d := diff.Unified(l.File, "reference", mine, ref); d == "" {
+				fmt.Fprintf(a.stdout, "%s is the same as the reference solution.\n", l.File)
+			} else {
+				fmt.Fprint(a.stdout, d)
+			}
+		}
+
+		if st, err := progress.Load(statePath); err != nil {
+			fmt.Fprintf(a.stderr, "warning: %v; progress not recorded\n", err)
+		} else {
+			st.RecordSolution(l.Code, time.Now())
+			a.saveProgress(st)
+		}
+		return nil
+	},
+}
+
+// lookupHints finds an exercise and its hints.
+func (a *app) lookupHints(code string) (registry.Lesson, []string, error) {
+	l, err := a.lookup(code)
+	if err != nil {
+		return registry.Lesson{}, nil, err
+	}
+	if !l.IsExercise() {
+		return registry.Lesson{}, nil, notFoundf("%s is not an exercise (see '%s list')", l.Code, program)
+	}
+	hints := solutions.Hints(l.Code)
+	if len(hints) == 0 {
+		return registry.Lesson{}, nil, notFoundf("%s has no hints", l.Code)
+	}
+	return l, hints, nil
+}
+
+// indent prefixes every non-empty line of s.
+func indent(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
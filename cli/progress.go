@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"first-golang/exercise"
+	"first-golang/progress"
+	"first-golang/runner"
+)
+
+var (
+	statePath     string
+	progressReset bool
+	progressAll   bool
+)
+
+// stateFlag registers -state for the commands that read or write progress.
+// Once set, the path stays the default, so commands typed into a repl
+// started with -state use the same file.
+func stateFlag(fs *flag.FlagSet) {
+	def := statePath
+	if def == "" {
+		def, _ = progress.DefaultPath()
+	}
+	fs.StringVar(&statePath, "state", def, "file that keeps your progress")
+}
+
+var progressCmd = &command{
+	name:    "progress",
+	args:    "[-v] [-reset] [-state file]",
+	summary: "show which lessons and exercises you have done",
+	help: `
+Progress shows, per chapter, how many lessons you have run and how many
+exercises passed their check, and suggests the next lesson to do.
+
+'run' and 'repl' record every lesson they run in the progress file. A
+lesson counts as done once it has run to the end, without panicking or
+timing out; an exercise also needs
+its check to pass. The check runs automatically after the exercise.`,
+	flags: func(fs *flag.FlagSet) {
+		stateFlag(fs)
+		fs.BoolVar(&progressAll, "v", false, "list every lesson, not just the chapters")
+		fs.BoolVar(&progressReset, "reset", false, "forget all progress")
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) > 0 {
+			return usagef("progress takes no arguments")
+		}
+		if statePath == "" {
+			return errors.New("no progress file: set -state")
+		}
+		if progressReset {
+			if err := os.Remove(statePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			fmt.Fprintf(a.stdout, "Progress in %s forgotten.\n", statePath)
+			return nil
+		}
+		st, err := progress.Load(statePath)
+		if err != nil {
+			return err
+		}
+		a.printProgress(st)
+		return nil
+	},
+}
+
+func (a *app) printProgress(st *progress.State) {
+	chapters, next := st.Summarize(a.lessons)
+	names := make(map[string]string) // chapter code -> directory, e.g. "04-more-types"
+	for _, l := range a.lessons {
+		names[l.Chapter()] = path.Dir(l.File)
+	}
+
+	fmt.Fprintf(a.stdout, "Progress (%s)\n\n", statePath)
+	done, total := 0, 0
+	for _, c := range chapters {
+		done += c.Done
+		total += c.Total
+		fmt.Fprintf(a.stdout, "  %-16s %3d/%-3d %s %3d%%", names[c.Name], c.Done, c.Total, bar(c.Done, c.Total), percent(c.Done, c.Total))
+		if c.Exercises > 0 {
+			fmt.Fprintf(a.stdout, "   exercises %d/%d", c.ExercisesPassed, c.Exercises)
+		}
+		fmt.Fprintln(a.stdout)
+		if progressAll {
+			a.printChapterLessons(st, c.Name)
+		}
+	}
+	fmt.Fprintf(a.stdout, "  %-16s %3d/%-3d %s %3d%%\n", "total", done, total, bar(done, total), percent(done, total))
+
+	fmt.Fprintln(a.stdout)
+	if next == nil {
+		fmt.Fprintln(a.stdout, "Every lesson is done.")
+		return
+	}
+	fmt.Fprintf(a.stdout, "Next: %s %s\n", next.Code, next.Title)
+	fmt.Fprintf(a.stdout, "  %s run %s\n", program, next.Code)
+}
+
+func (a *app) printChapterLessons(st *progress.State, chapter string) {
+	for _, l := range a.lessons {
+		if l.Chapter() != chapter {
+			continue
+		}
+		mark := " "
+		if st.Done(l) {
+			mark = "x"
+		}
+		fmt.Fprintf(a.stdout, "      [%s] %s  %s", mark, l.Code, l.Title)
+		if r, ok := st.Lessons[l.Code]; ok {
+			fmt.Fprintf(a.stdout, "%*s ran %d times, last %s", max(0, 40-len(l.Title)), "", r.Runs, r.LastStatus)
+			switch {
+			case r.Passed:
+				fmt.Fprint(a.stdout, ", check passed")
+			case r.LastError != "":
+				fmt.Fprint(a.stdout, ", check failed")
+			}
+		}
+		fmt.Fprintln(a.stdout)
+	}
+}
+
+func bar(done, total int) string {
+	const width = 20
+	n := 0
+	if total > 0 {
+		n = done * width / total
+	}
+	return "[" + strings.Repeat("#", n) + strings.Repeat(".", width-n) + "]"
+}
+
+func percent(done, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return done * 100 / total
+}
+
+// checkAndRecord runs the check of every exercise that finished normally,
+// writes the verdicts to w unless it is nil, and records the runs and
+// checks in the progress file. It returns the verdict of each exercise
+// it checked, by code. Failing to read or write the progress file is only
+// a warning: the lessons did run.
+func (a *app) checkAndRecord(w io.Writer, results []runner.Result, opts runner.Options) map[string]exercise.Verdict {
+	st, err := progress.Load(statePath)
+	if err != nil {
+		fmt.Fprintf(a.stderr, "warning: %v; progress not recorded\n", err)
+	}
+	checks := make(map[string]exercise.Verdict)
+	for _, r := range results {
+		now := time.Now()
+		if st != nil {
+			st.RecordRun(r.Lesson.Code, r.Status.String(), now)
+		}
+		if !r.Lesson.IsExercise() || r.Failed() {
+			continue
+		}
+		v := runner.Check(r.Lesson, opts)
+		checks[r.Lesson.Code] = v
+		if w != nil {
+			runner.ReportCheck(w, r.Lesson, v)
+		}
+		if st != nil {
+			st.RecordCheck(r.Lesson.Code, v.Err(), now)
+		}
+	}
+	a.saveProgress(st)
+	return checks
+}
+
+// saveProgress writes st to the progress file, warning if that fails.
+func (a *app) saveProgress(st *progress.State) {
+	if st == nil || statePath == "" {
+		return
+	}
+	if err := st.Save(statePath); err != nil {
+		fmt.Fprintf(a.stderr, "warning: saving progress: %v\n", err)
+	}
+}
+
+// failedChecks counts the checks that did not pass.
+func failedChecks(checks map[string]exercise.Verdict) int {
+	n := 0
+	for _, v := range checks {
+		if !v.Passed() {
+			n++
+		}
+	}
+	return n
+}
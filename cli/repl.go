@@ -0,0 +1,358 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"first-golang/i18n"
+	"first-golang/registry"
+	"first-golang/runner"
+	"golang.org/x/term"
+)
+
+const replPrompt = "golang> "
+
+const replHelp = `
+Repl starts an interactive session. Type a lesson pattern (04-18, 05,
+04-10:04-18, 07-0*) to run it; Tab completes lesson codes, command names
+and lesson titles. Inside the session:
+
+	n, next          run the lesson after the current one
+	p, prev          run the lesson before the current one
+	r, rerun         run the last selection again
+	history          show what ran in this session
+	lang [zh-TW|en]  show or switch the language lessons narrate in
+	quit, exit       leave (Ctrl-D works too)
+
+Every other command (list, show, info, search, ...) works as on the
+command line, except watch and serve: they run until Ctrl-C, which the
+session cannot pass on to them, so start them from the shell instead.`
+
+var replTimeout time.Duration
+
+var replCmd = &command{
+	name:    "repl",
+	args:    "[-timeout d] [-state file]",
+	summary: "start an interactive session",
+	help:    replHelp,
+	flags: func(fs *flag.FlagSet) {
+		fs.DurationVar(&replTimeout, "timeout", runner.DefaultTimeout, "abandon a lesson after this long (0 for no limit)")
+		stateFlag(fs)
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) > 0 {
+			return usagef("repl takes no arguments")
+		}
+		return a.repl(replTimeout)
+	},
+}
+
+// A lineReader yields input lines, returning io.EOF at the end.
+type lineReader interface {
+	ReadLine() (string, error)
+}
+
+// scanReader reads lines from a non-terminal stdin, e.g. a pipe.
+type scanReader struct {
+	*bufio.Scanner
+}
+
+func (r scanReader) ReadLine() (string, error) {
+	if !r.Scan() {
+		if err := r.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.Text(), nil
+}
+
+// session is the state of one REPL run.
+type session struct {
+	app     *app // the app with stdout and stderr pointing at the session
+	out     io.Writer
+	timeout time.Duration
+	cur     int               // index in app.lessons of the current lesson, -1 before any
+	last    []registry.Lesson // last selection, for rerun
+	history []historyEntry
+}
+
+type historyEntry struct {
+	at     time.Time
+	result runner.Result
+}
+
+func (a *app) repl(timeout time.Duration) error {
+	var (
+		in  lineReader
+		out io.Writer = a.stdout
+	)
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer term.Restore(fd, state)
+
+		t := term.NewTerminal(struct {
+			io.Reader
+			io.Writer
+		}{os.Stdin, a.stdout}, replPrompt)
+		if w, h, err := term.GetSize(fd); err == nil && w > 0 {
+			t.SetSize(w, h)
+		}
+		in, out = t, t
+		defer fmt.Fprintln(t)
+
+		s := a.newSession(out, timeout)
+		t.AutoCompleteCallback = s.complete(t)
+		return s.loop(in)
+	}
+
+	in = scanReader{bufio.NewScanner(os.Stdin)}
+	return a.newSession(out, timeout).loop(in)
+}
+
+func (a *app) newSession(out io.Writer, timeout time.Duration) *session {
+	sub := *a
+	sub.stdout, sub.stderr = out, out
+	return &session{app: &sub, out: out, timeout: timeout, cur: -1}
+}
+
+func (s *session) loop(in lineReader) error {
+	fmt.Fprintf(s.out, "%d lessons loaded. Type 'help' for commands, Tab to complete.\n", len(s.app.lessons))
+	for {
+		line, err := in.ReadLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if quit := s.handle(strings.Fields(line)); quit {
+			return nil
+		}
+	}
+}
+
+// handle executes one input line and reports whether the session ends.
+func (s *session) handle(words []string) (quit bool) {
+	if len(words) == 0 {
+		return false
+	}
+	switch words[0] {
+	case "quit", "exit", "q":
+		return true
+	case "n", "next":
+		s.step(+1)
+	case "p", "prev":
+		s.step(-1)
+	case "r", "rerun":
+		if s.last == nil {
+			fmt.Fprintln(s.out, "Nothing has run yet.")
+			break
+		}
+		s.run(s.last)
+	case "history":
+		s.printHistory()
+	case "lang":
+		s.lang(words[1:])
+	case "help", "?":
+		if len(words) == 1 {
+			fmt.Fprintln(s.out, strings.TrimSpace(replHelp))
+			break
+		}
+		s.exec(lookupCommand("help"), words[1:])
+	case "run":
+		s.runPatterns(words[1:])
+	default:
+		if cmd := lookupCommand(words[0]); cmd != nil && cmd.name != "repl" {
+			if cmd.untilInterrupt {
+				fmt.Fprintf(s.out, "%s runs until Ctrl-C, which does not reach it inside the repl; run '%s %s' from the shell.\n",
+					cmd.name, program, strings.Join(words, " "))
+				break
+			}
+			s.exec(cmd, words[1:])
+			break
+		}
+		s.runPatterns(words)
+	}
+	return false
+}
+
+func (s *session) exec(cmd *command, args []string) {
+	if err := s.app.exec(cmd, args); err != nil {
+		fmt.Fprintf(s.out, "%s: %v\n", cmd.name, err)
+	}
+}
+
+// lang prints the narration language or switches to another one.
+func (s *session) lang(args []string) {
+	switch len(args) {
+	case 0:
+		fmt.Fprintln(s.out, i18n.Current())
+	case 1:
+		l, err := i18n.Parse(args[0])
+		if err != nil {
+			fmt.Fprintf(s.out, "lang: %v\n", err)
+			return
+		}
+		i18n.Set(l)
+	default:
+		fmt.Fprintln(s.out, "lang: takes at most one language")
+	}
+}
+
+func (s *session) runPatterns(patterns []string) {
+	if len(patterns) == 0 {
+		fmt.Fprintln(s.out, "run: missing lesson pattern")
+		return
+	}
+	lessons, err := s.app.selectLessons(patterns)
+	if err != nil {
+		fmt.Fprintln(s.out, err)
+		return
+	}
+	s.run(lessons)
+}
+
+// step runs the lesson delta places away from the current one.
+func (s *session) step(delta int) {
+	i := s.cur + delta
+	if s.cur < 0 {
+		i = 0
+	}
+	if i < 0 || i >= len(s.app.lessons) {
+		fmt.Fprintln(s.out, "No more lessons in that direction.")
+		return
+	}
+	s.run(s.app.lessons[i : i+1])
+}
+
+func (s *session) run(lessons []registry.Lesson) {
+	s.last = lessons
+	opts := runner.Options{Timeout: s.timeout}
+	for _, l := range lessons {
+		fmt.Fprintf(s.out, "=== %s %s ===\n", l.Code, l.Title)
+		r := runner.Run(l, s.out, opts)
+		runner.Report(s.out, r)
+		s.app.checkAndRecord(s.out, []runner.Result{r}, opts)
+		s.history = append(s.history, historyEntry{at: time.Now(), result: r})
+		s.cur = slices.IndexFunc(s.app.lessons, func(x registry.Lesson) bool {
+			return x.Code == l.Code
+		})
+	}
+}
+
+func (s *session) printHistory() {
+	if len(s.history) == 0 {
+		fmt.Fprintln(s.out, "Nothing has run yet.")
+		return
+	}
+	for i, h := range s.history {
+		r := h.result
+		fmt.Fprintf(s.out, "%3d  %s  %s  %-40s %-9s %v\n", i+1, h.at.Format("15:04:05"),
+			r.Lesson.Code, r.Lesson.Title, r.Status, r.Elapsed.Round(time.Microsecond))
+	}
+}
+
+// complete returns the Tab handler for t. It completes the word under the
+// cursor as a command or lesson code, and otherwise treats the text after
+// the command as the start of a lesson title and replaces it by the code.
+func (s *session) complete(t *term.Terminal) func(line string, pos int, key rune) (string, int, bool) {
+	return func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+		head, tail := line[:pos], line[pos:]
+
+		start := strings.LastIndexByte(head, ' ') + 1
+		word := head[start:]
+		var candidates []string
+		if start == 0 {
+			candidates = s.commandNames()
+		}
+		for _, l := range s.app.lessons {
+			candidates = append(candidates, l.Code)
+		}
+		matches := filterPrefix(candidates, word)
+
+		if len(matches) == 0 {
+			// Try a title: everything after the command word, if any.
+			titleStart := 0
+			if cmd, _, ok := strings.Cut(head, " "); ok && lookupCommand(cmd) != nil {
+				titleStart = len(cmd) + 1
+			}
+			text := strings.ToLower(strings.TrimSpace(head[titleStart:]))
+			var byTitle []registry.Lesson
+			for _, l := range s.app.lessons {
+				if text != "" && strings.HasPrefix(strings.ToLower(l.Title), text) {
+					byTitle = append(byTitle, l)
+				}
+			}
+			switch len(byTitle) {
+			case 0:
+				return "", 0, false
+			case 1:
+				newHead := head[:titleStart] + byTitle[0].Code + " "
+				return newHead + tail, len(newHead), true
+			default:
+				for _, l := range byTitle {
+					fmt.Fprintf(t, "  %s  %s\n", l.Code, l.Title)
+				}
+				return line, pos, true
+			}
+		}
+
+		if len(matches) == 1 {
+			newHead := head[:start] + matches[0] + " "
+			return newHead + tail, len(newHead), true
+		}
+		if common := commonPrefix(matches); len(common) > len(word) {
+			newHead := head[:start] + common
+			return newHead + tail, len(newHead), true
+		}
+		fmt.Fprintln(t, "  "+strings.Join(matches, "  "))
+		return line, pos, true
+	}
+}
+
+func (s *session) commandNames() []string {
+	names := []string{"next", "prev", "rerun", "history", "lang", "quit", "exit"}
+	for _, c := range commands {
+		if c.name != "repl" && !c.untilInterrupt {
+			names = append(names, c.name)
+		}
+	}
+	return names
+}
+
+func filterPrefix(words []string, prefix string) []string {
+	var out []string
+	for _, w := range words {
+		if strings.HasPrefix(w, prefix) {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+func commonPrefix(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	prefix := words[0]
+	for _, w := range words[1:] {
+		for !strings.HasPrefix(w, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"first-golang/runner"
+	"first-golang/web"
+)
+
+var (
+	serveAddr    string
+	serveTimeout time.Duration
+)
+
+var serveCmd = &command{
+	name:    "serve",
+	args:    "[-addr host:port] [-timeout d]",
+	summary: "browse and run the lessons in a web browser",
+	help: `
+Serve starts a web server with an index of the lessons by chapter and a
+page per lesson showing its source. The Run button on a lesson page runs
+it on the server and streams its output into the page, under the same
+supervision as 'run': a panic is reported and a lesson still running
+after -timeout is abandoned.
+
+Everything is served from this program, so it works offline. It listens
+on localhost only unless -addr says otherwise.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.StringVar(&serveAddr, "addr", "localhost:3999", "address to listen on")
+		fs.DurationVar(&serveTimeout, "timeout", runner.DefaultTimeout, "abandon a lesson after this long (0 for no limit)")
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) > 0 {
+			return usagef("serve takes no arguments")
+		}
+		ln, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Serving %d lessons at http://%s/ (Ctrl-C to stop)\n", len(a.lessons), ln.Addr())
+		srv := &http.Server{
+			Handler:           web.New(a.lessons, runner.Options{Timeout: serveTimeout}),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		return srv.Serve(ln)
+	},
+	untilInterrupt: true,
+}
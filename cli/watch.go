@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"first-golang/i18n"
+	"first-golang/registry"
+	"first-golang/runner"
+	"golang.org/x/term"
+)
+
+var (
+	watchInterval time.Duration
+	watchTimeout  time.Duration
+	watchClear    bool
+)
+
+var watchCmd = &command{
+	name:    "watch",
+	args:    "[-interval d] [-timeout d] [-clear=false] [-state file] <code>",
+	summary: "re-run a lesson every time its package changes",
+	help: `
+Watch runs a lesson, then keeps polling the .go files of its package and
+reruns it whenever one changes: it rebuilds the program with 'go build',
+clears the screen, and either shows the compile errors or runs the
+lesson. Exercises are checked and recorded in the progress file, as with
+'run'. Stop it with Ctrl-C.
+
+Watch needs the go command, since it rebuilds from source.`,
+	flags: func(fs *flag.FlagSet) {
+		fs.DurationVar(&watchInterval, "interval", 500*time.Millisecond, "how often to look for changes")
+		fs.DurationVar(&watchTimeout, "timeout", runner.DefaultTimeout, "abandon a lesson after this long (0 for no limit)")
+		fs.BoolVar(&watchClear, "clear", true, "clear the screen before each run (only on a terminal)")
+		stateFlag(fs)
+	},
+	run: func(a *app, _ *flag.FlagSet, args []string) error {
+		if len(args) != 1 {
+			return usagef("watch takes exactly one lesson code")
+		}
+		l, err := a.lookup(args[0])
+		if err != nil {
+			return err
+		}
+		if watchInterval <= 0 {
+			return usagef("-interval must be positive")
+		}
+		goCmd, err := exec.LookPath("go")
+		if err != nil {
+			return errors.New("watch needs the go command to rebuild the lessons")
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return a.watch(ctx, goCmd, l)
+	},
+	untilInterrupt: true,
+}
+
+func (a *app) watch(ctx context.Context, goCmd string, l registry.Lesson) error {
+	tmp, err := os.MkdirTemp("", "first-golang-watch-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	bin := filepath.Join(tmp, "lessons")
+
+	dir := filepath.Dir(l.Path())
+	clear := watchClear
+	if f, ok := a.stdout.(*os.File); !ok || !term.IsTerminal(int(f.Fd())) {
+		clear = false
+	}
+
+	var last snapshot
+	tick := time.NewTicker(watchInterval)
+	defer tick.Stop()
+	for {
+		snap, err := takeSnapshot(dir)
+		if err != nil {
+			return err
+		}
+		if !snap.equal(last) {
+			last = snap
+			if clear {
+				fmt.Fprint(a.stdout, "\x1b[H\x1b[2J")
+			}
+			fmt.Fprintf(a.stdout, "[%s] %s %s (watching %s, Ctrl-C to stop)\n\n",
+				time.Now().Format("15:04:05"), l.Code, l.Title, filepath.Base(dir))
+			a.rebuildAndRun(ctx, goCmd, bin, l)
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(a.stdout)
+			return nil
+		case <-tick.C:
+		}
+	}
+}
+
+// rebuildAndRun builds the program into bin and runs the lesson with it,
+// in a process of its own, so the run sees the code as it is now. A
+// compile error is shown in place of the output.
+func (a *app) rebuildAndRun(ctx context.Context, goCmd, bin string, l registry.Lesson) {
+	build := exec.CommandContext(ctx, goCmd, "build", "-o", bin, ".")
+	build.Dir = registry.Root()
+	if out, err := build.CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		fmt.Fprintln(a.stdout, "--- build failed:")
+		a.stdout.Write(out)
+		return
+	}
+
+	args := []string{"-lang", string(i18n.Current()), "run", "-timeout", watchTimeout.String()}
+	if statePath != "" {
+		args = append(args, "-state", statePath)
+	}
+	run := exec.CommandContext(ctx, bin, append(args, l.Code)...)
+	run.Dir = registry.Root()
+	run.Stdout, run.Stderr = a.stdout, a.stderr
+	start := time.Now()
+	err := run.Run()
+	if ctx.Err() != nil {
+		return
+	}
+	fmt.Fprintln(a.stdout)
+	var exit *exec.ExitError
+	switch {
+	case err == nil:
+		fmt.Fprintf(a.stdout, "--- finished in %v; waiting for changes\n", time.Since(start).Round(time.Millisecond))
+	case errors.As(err, &exit):
+		fmt.Fprintf(a.stdout, "--- exit status %d; waiting for changes\n", exit.ExitCode())
+	default:
+		fmt.Fprintf(a.stdout, "--- %v; waiting for changes\n", err)
+	}
+}
+
+// snapshot is the modification time and size of every .go file in a
+// directory, for polling it for changes.
+type snapshot map[string]fileStamp
+
+type fileStamp struct {
+	mod  time.Time
+	size int64
+}
+
+func takeSnapshot(dir string) (snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	snap := make(snapshot)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") {
+			continue
+		}
+		info, err := e.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			continue // removed while we looked
+		}
+		if err != nil {
+			return nil, err
+		}
+		snap[e.Name()] = fileStamp{info.ModTime(), info.Size()}
+	}
+	return snap, nil
+}
+
+func (s snapshot) equal(t snapshot) bool {
+	// nil is no snapshot yet, which differs from everything.
+	if s == nil || t == nil || len(s) != len(t) {
+		return false
+	}
+	for name, stamp := range s {
+		if other, ok := t[name]; !ok || !other.mod.Equal(stamp.mod) || other.size != stamp.size {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numbered returns the lines 1 to n, with the lines in change replaced,
+// or dropped when replaced by "".
+func numbered(n int, change map[int]string) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		if s, ok := change[i]; ok {
+			if s != "" {
+				b.WriteString(s + "\n")
+			}
+			continue
+		}
+		fmt.Fprintf(&b, "%d\n", i)
+	}
+	return b.String()
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"equal", "a\nb\n", "a\nb\n", ""},
+		{"insert", "a\nb\n", "a\nc\nb\n", "@@ -1,2 +1,3 @@\n a\n+c\n b\n"},
+		{"from empty", "", "a\n", "@@ -1,0 +1,1 @@\n+a\n"},
+		{
+			// Six unchanged lines between two changes: one hunk.
+			"merged hunks",
+			numbered(20, nil),
+			numbered(20, map[int]string{3: "x", 10: "y"}),
+			"@@ -1,13 +1,13 @@\n 1\n 2\n-3\n+x\n 4\n 5\n 6\n 7\n 8\n 9\n-10\n+y\n 11\n 12\n 13\n",
+		},
+		{
+			// Seven unchanged lines: two hunks.
+			"separate hunks",
+			numbered(20, nil),
+			numbered(20, map[int]string{3: "x", 11: "y"}),
+			"@@ -1,6 +1,6 @@\n 1\n 2\n-3\n+x\n 4\n 5\n 6\n" +
+				"@@ -8,7 +8,7 @@\n 8\n 9\n 10\n-11\n+y\n 12\n 13\n 14\n",
+		},
+		{
+			"delete at end",
+			numbered(10, nil),
+			numbered(10, map[int]string{10: ""}),
+			"@@ -7,4 +7,3 @@\n 7\n 8\n 9\n-10\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want != "" {
+				want = "--- old\n+++ new\n" + want
+			}
+			if got := Unified("old", "new", tt.a, tt.b); got != want {
+				t.Errorf("Unified =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Package doctor cross-checks the lesson registry against the source
+// tree: the files lessons claim to live in, the tour URL in each file's
+// header, the exported RunXxx functions, and the numbered files that no
+// lesson comes from.
+package doctor
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"first-golang/i18n"
+	"first-golang/registry"
+	"first-golang/tour"
+)
+
+// Problem is one inconsistency, located in a file relative to the module
+// root, and at a line when there is one to point at.
+type Problem struct {
+	File string
+	Line int
+	Msg  string
+}
+
+func (p Problem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Msg)
+	}
+	return fmt.Sprintf("%s: %s", p.File, p.Msg)
+}
+
+var (
+	// chapterDir matches a chapter directory such as "04-more-types".
+	chapterDir = regexp.MustCompile(`^(\d\d)-`)
+	// lessonFile matches the page numbers at the start of a lesson file:
+	// "18-Exercise:Slices.go" or "07&08-Exercise:Equivalent-Binary-Trees.go".
+	lessonFile = regexp.MustCompile(`^(\d\d(?:&\d\d)*)-.*\.go$`)
+)
+
+// Examine checks lessons against the tree under root and returns the
+// problems found, ordered by file and line. Files whose name does not
+// start with a page number, such as helpers, and the 00 templates are
+// not lesson files and are left alone.
+func Examine(root string, lessons []registry.Lesson) ([]Problem, error) {
+	var problems []Problem
+	report := func(file string, line int, format string, args ...any) {
+		problems = append(problems, Problem{file, line, fmt.Sprintf(format, args...)})
+	}
+
+	// byFile maps a source file to the lessons registered from it, and
+	// runFuncs maps a file to the names of the Run functions registered.
+	byFile := make(map[string][]registry.Lesson)
+	runFuncs := make(map[string]map[string]bool)
+	urls := make(map[string]string)
+	for _, l := range lessons {
+		if prev, dup := urls[l.TourURL]; dup && l.TourURL != "" {
+			report(l.File, 0, "lesson %s has the same tour URL as %s: %s", l.Code, prev, l.TourURL)
+		}
+		urls[l.TourURL] = l.Code
+
+		if l.File == "" {
+			report("(registry)", 0, "lesson %s has no source file", l.Code)
+			continue
+		}
+		if _, err := os.Stat(l.Path()); err != nil {
+			report(l.File, 0, "lesson %s: source file does not exist", l.Code)
+			continue
+		}
+		byFile[l.File] = append(byFile[l.File], l)
+		checkNames(l, report)
+
+		name, file := l.RunFunc()
+		if runFuncs[l.File] == nil {
+			runFuncs[l.File] = make(map[string]bool)
+		}
+		runFuncs[l.File][name] = true
+		if file != "" && !sameFile(file, l.File) {
+			report(l.File, 0, "lesson %s runs %s, which is defined in %s", l.Code, name, file)
+		}
+	}
+
+	dirs, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dirs {
+		if !d.IsDir() || !chapterDir.MatchString(d.Name()) {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(root, d.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			m := lessonFile.FindStringSubmatch(f.Name())
+			if f.IsDir() || m == nil || m[1] == "00" {
+				continue
+			}
+			rel := path.Join(d.Name(), f.Name())
+			if err := checkFile(root, rel, strings.Split(m[1], "&"), byFile[rel], runFuncs[rel], report); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	slices.SortStableFunc(problems, func(a, b Problem) int {
+		return cmp.Or(strings.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line))
+	})
+	return problems, nil
+}
+
+// checkNames checks that a lesson's code agrees with its directory, its
+// file name and its tour URL.
+func checkNames(l registry.Lesson, report func(string, int, string, ...any)) {
+	chapter, page, _ := strings.Cut(l.Code, "-")
+	dir, file := path.Split(l.File)
+	if m := chapterDir.FindStringSubmatch(path.Base(dir)); m == nil || m[1] != chapter {
+		report(l.File, 0, "lesson %s is not in a directory of chapter %s", l.Code, chapter)
+	}
+	if m := lessonFile.FindStringSubmatch(file); m == nil || !slices.Contains(strings.Split(m[1], "&"), page) {
+		report(l.File, 0, "lesson %s is in a file not numbered %s", l.Code, page)
+	}
+	if n, err := strconv.Atoi(page); err == nil && path.Base(l.TourURL) != strconv.Itoa(n) {
+		report(l.File, 0, "lesson %s has tour URL %s, which is not page %d", l.Code, l.TourURL, n)
+	}
+}
+
+// checkFile checks one numbered lesson file: its header URL, and that
+// every page it is numbered for and every exported Run function in it
+// is registered.
+func checkFile(root, rel string, pages []string, lessons []registry.Lesson, registered map[string]bool, report func(string, int, string, ...any)) error {
+	src, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+	if err != nil {
+		return err
+	}
+
+	url, ok := tour.Header(src)
+	switch {
+	case !ok:
+		report(rel, 1, "missing '// %s...' header", tour.BaseURL)
+	case len(lessons) > 0:
+		// The header names one page; a shared file is checked against
+		// its first lesson.
+		if l := lessons[0]; url != l.TourURL {
+			report(rel, 1, "header URL %s does not match lesson %s (%s)", url, l.Code, l.TourURL)
+		}
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, rel, src, parser.SkipObjectResolution)
+	if err != nil {
+		report(rel, 0, "does not parse: %v", err)
+		return nil
+	}
+	var runs []*ast.FuncDecl
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.IsExported() && strings.HasPrefix(fn.Name.Name, "Run") {
+			runs = append(runs, fn)
+		}
+	}
+
+	if len(lessons) == 0 {
+		if len(runs) == 0 {
+			report(rel, 0, "orphan file: no lesson is registered from it and it has no Run function")
+		} else {
+			report(rel, 0, "orphan file: no lesson is registered from it")
+		}
+	}
+	chapter := chapterDir.FindStringSubmatch(path.Dir(rel))[1]
+	for _, page := range pages {
+		code := chapter + "-" + page
+		if !slices.ContainsFunc(lessons, func(l registry.Lesson) bool { return l.Code == code }) && len(lessons) > 0 {
+			var others []string
+			for _, l := range lessons {
+				others = append(others, l.Code)
+			}
+			report(rel, 0, "file is numbered for page %s, but only %s is registered from it", page, strings.Join(others, ", "))
+		}
+	}
+	checkMessages(fset, f, rel, report)
+	for _, fn := range runs {
+		if !registered[fn.Name.Name] {
+			report(rel, fset.Position(fn.Pos()).Line, "%s is exported but not registered as a lesson", fn.Name.Name)
+		}
+	}
+	return nil
+}
+
+// checkMessages checks that every message key the file passes to the
+// i18n package is in the catalog.
+func checkMessages(fset *token.FileSet, f *ast.File, rel string, report func(string, int, string, ...any)) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "i18n" {
+			return true
+		}
+		for _, arg := range call.Args {
+			lit, ok := arg.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				continue
+			}
+			if key, err := strconv.Unquote(lit.Value); err == nil && !i18n.Has(key) {
+				report(rel, fset.Position(lit.Pos()).Line, "message %q is not in the i18n catalog", key)
+			}
+			break // the key is the first string argument
+		}
+		return true
+	})
+}
+
+// sameFile reports whether file, as recorded in the binary, is rel. Only
+// the directory and the base name are compared, so -trimpath builds,
+// whose paths are not absolute, compare equal too.
+func sameFile(file, rel string) bool {
+	file = filepath.ToSlash(file)
+	return strings.HasSuffix(file, "/"+rel) || file == rel
+}
+
+// Summary is a one-line tally for the end of a report.
+func Summary(problems []Problem) string {
+	switch len(problems) {
+	case 0:
+		return "No problems found."
+	case 1:
+		return "1 problem found."
+	}
+	return fmt.Sprintf("%d problems found.", len(problems))
+}
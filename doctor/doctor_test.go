@@ -0,0 +1,143 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"first-golang/registry"
+)
+
+// writeTree creates the files under a temporary root and returns it.
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, src := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestExamineOrphans(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		"04-more-types/18-Exercise:Slices.go":   "// https://go.dev/tour/moretypes/18\npackage moreTypes\n\nfunc RunMoreTypes18() {}\n",
+		"04-more-types/19-Maps.go":              "// https://go.dev/tour/moretypes/19\npackage moreTypes\n",
+		"04-more-types/00-template.go":          "package moreTypes\n",
+		"04-more-types/helper.go":               "package moreTypes\n",
+		"notes/01-intro.go":                     "package notes\n",
+		"07-concurrency/07&08-Exercise:Tree.go": "package concurrency\n",
+	})
+	problems, err := Examine(root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.String())
+	}
+	want := []string{
+		"04-more-types/18-Exercise:Slices.go: orphan file: no lesson is registered from it",
+		"04-more-types/18-Exercise:Slices.go:4: RunMoreTypes18 is exported but not registered as a lesson",
+		"04-more-types/19-Maps.go: orphan file: no lesson is registered from it and it has no Run function",
+		"07-concurrency/07&08-Exercise:Tree.go:1: missing '// https://go.dev/tour/...' header",
+		"07-concurrency/07&08-Exercise:Tree.go: orphan file: no lesson is registered from it and it has no Run function",
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("problems:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	const rel = "05-methods/03-Methods-continued.go"
+	tests := []struct {
+		name       string
+		src        string
+		lessons    []registry.Lesson
+		registered []string
+		want       []Problem
+	}{
+		{
+			name:       "consistent",
+			src:        "// https://go.dev/tour/methods/3\npackage methods\n\nfunc RunMethods03() {}\n",
+			lessons:    []registry.Lesson{{Code: "05-03", TourURL: "https://go.dev/tour/methods/3"}},
+			registered: []string{"RunMethods03"},
+		},
+		{
+			name:       "wrong header",
+			src:        "// https://go.dev/tour/methods/4\npackage methods\n\nfunc RunMethods03() {}\n",
+			lessons:    []registry.Lesson{{Code: "05-03", TourURL: "https://go.dev/tour/methods/3"}},
+			registered: []string{"RunMethods03"},
+			want:       []Problem{{rel, 1, "header URL https://go.dev/tour/methods/4 does not match lesson 05-03 (https://go.dev/tour/methods/3)"}},
+		},
+		{
+			name:       "no header",
+			src:        "package methods\n\nfunc RunMethods03() {}\n",
+			lessons:    []registry.Lesson{{Code: "05-03", TourURL: "https://go.dev/tour/methods/3"}},
+			registered: []string{"RunMethods03"},
+			want:       []Problem{{rel, 1, "missing '// https://go.dev/tour/...' header"}},
+		},
+		{
+			name:       "unregistered Run function",
+			src:        "// https://go.dev/tour/methods/3\npackage methods\n\nfunc RunMethods03() {}\n\nfunc RunMethods03b() {}\n",
+			lessons:    []registry.Lesson{{Code: "05-03", TourURL: "https://go.dev/tour/methods/3"}},
+			registered: []string{"RunMethods03"},
+			want:       []Problem{{rel, 6, "RunMethods03b is exported but not registered as a lesson"}},
+		},
+		{
+			name:       "unknown message",
+			src:        "// https://go.dev/tour/methods/3\npackage methods\n\nfunc RunMethods03() { i18n.Fprintln(w, \"05-03.no-such-key\") }\n",
+			lessons:    []registry.Lesson{{Code: "05-03", TourURL: "https://go.dev/tour/methods/3"}},
+			registered: []string{"RunMethods03"},
+			want:       []Problem{{rel, 4, `message "05-03.no-such-key" is not in the i18n catalog`}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeTree(t, map[string]string{rel: tt.src})
+			registered := make(map[string]bool)
+			for _, name := range tt.registered {
+				registered[name] = true
+			}
+			var got []Problem
+			report := func(file string, line int, format string, args ...any) {
+				got = append(got, Problem{file, line, fmt.Sprintf(format, args...)})
+			}
+			if err := checkFile(root, rel, []string{"03"}, tt.lessons, registered, report); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("problems = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckNames(t *testing.T) {
+	tests := []struct {
+		lesson registry.Lesson
+		want   int // number of problems
+	}{
+		{registry.Lesson{Code: "04-18", File: "04-more-types/18-Exercise:Slices.go", TourURL: "https://go.dev/tour/moretypes/18"}, 0},
+		{registry.Lesson{Code: "07-08", File: "07-concurrency/07&08-Exercise:Tree.go", TourURL: "https://go.dev/tour/concurrency/8"}, 0},
+		{registry.Lesson{Code: "04-18", File: "05-methods/18-Exercise:Slices.go", TourURL: "https://go.dev/tour/moretypes/18"}, 1},
+		{registry.Lesson{Code: "04-18", File: "04-more-types/17-Range.go", TourURL: "https://go.dev/tour/moretypes/18"}, 1},
+		{registry.Lesson{Code: "04-18", File: "04-more-types/18-Exercise:Slices.go", TourURL: "https://go.dev/tour/moretypes/17"}, 1},
+	}
+	for _, tt := range tests {
+		n := 0
+		checkNames(tt.lesson, func(string, int, string, ...any) { n++ })
+		if n != tt.want {
+			t.Errorf("checkNames(%s in %s, %s) reported %d problems, want %d", tt.lesson.Code, tt.lesson.File, tt.lesson.TourURL, n, tt.want)
+		}
+	}
+}
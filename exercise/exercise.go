@@ -0,0 +1,142 @@
+// Package exercise validates the solutions of the tour's exercises.
+//
+// Each exercise has a Checker that tries the solution on a number of
+// cases and reports each one to a T, much like a test does:
+//
+//	func checkMoreTypes23(t *exercise.T) {
+//		for _, c := range tourio.WordCountCases {
+//			t.Equal(fmt.Sprintf("WordCount(%q)", c.In), WordCount(c.In), c.Want)
+//		}
+//	}
+//
+// Running a Checker yields a Verdict listing every case and whether it
+// passed, so a failure says exactly which inputs are wrong.
+package exercise
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Checker validates the solution of one exercise.
+type Checker func(t *T)
+
+// Case is the outcome of one check.
+type Case struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Got     string `json:"got,omitempty"`
+	Want    string `json:"want,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (c Case) String() string {
+	switch {
+	case c.Passed:
+		return c.Name
+	case c.Got != "" || c.Want != "":
+		return fmt.Sprintf("%s = %s, want %s", c.Name, c.Got, c.Want)
+	default:
+		return fmt.Sprintf("%s: %s", c.Name, c.Message)
+	}
+}
+
+// T collects the cases of a Checker.
+type T struct {
+	cases []Case
+}
+
+// Equal records a case that passes when got and want are deeply equal.
+func (t *T) Equal(name string, got, want any) {
+	c := Case{Name: name, Passed: reflect.DeepEqual(got, want)}
+	if !c.Passed {
+		c.Got, c.Want = fmt.Sprintf("%#v", got), fmt.Sprintf("%#v", want)
+	}
+	t.cases = append(t.cases, c)
+}
+
+// True records a case that passes when ok is true; the message explains
+// the failure.
+func (t *T) True(name string, ok bool, format string, args ...any) {
+	c := Case{Name: name, Passed: ok}
+	if !ok {
+		c.Message = fmt.Sprintf(format, args...)
+	}
+	t.cases = append(t.cases, c)
+}
+
+// NoError records a case that passes when err is nil.
+func (t *T) NoError(name string, err error) {
+	c := Case{Name: name, Passed: err == nil}
+	if err != nil {
+		c.Message = err.Error()
+	}
+	t.cases = append(t.cases, c)
+}
+
+// Verdict is the result of running a Checker.
+type Verdict struct {
+	Cases []Case `json:"cases"`
+}
+
+// Run runs the checker and returns its verdict. A panic in the solution
+// ends the check with a failing case saying so.
+func Run(check Checker) (v Verdict) {
+	t := &T{}
+	defer func() {
+		if p := recover(); p != nil {
+			t.cases = append(t.cases, Case{Name: "panic", Message: fmt.Sprint(p)})
+		}
+		v = Verdict{Cases: t.cases}
+	}()
+	check(t)
+	return v
+}
+
+// Failed returns a verdict with the single failing case name, for when
+// the checker could not produce one, e.g. because it timed out.
+func Failed(name, format string, args ...any) Verdict {
+	return Verdict{Cases: []Case{{Name: name, Message: fmt.Sprintf(format, args...)}}}
+}
+
+// Passed reports whether every case passed. A checker that reported no
+// case at all has not shown anything, so that is not a pass.
+func (v Verdict) Passed() bool {
+	return len(v.Cases) > 0 && len(v.Failures()) == 0
+}
+
+// Failures returns the cases that did not pass.
+func (v Verdict) Failures() []Case {
+	var failed []Case
+	for _, c := range v.Cases {
+		if !c.Passed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// Score returns how many cases passed, out of how many.
+func (v Verdict) Score() (passed, total int) {
+	return len(v.Cases) - len(v.Failures()), len(v.Cases)
+}
+
+// Err returns nil if the verdict passed, and otherwise an error naming
+// the first failing case and how many others failed.
+func (v Verdict) Err() error {
+	if v.Passed() {
+		return nil
+	}
+	failed := v.Failures()
+	if len(failed) == 0 {
+		return errors.New("no cases were checked")
+	}
+	var b strings.Builder
+	b.WriteString(failed[0].String())
+	if n := len(failed) - 1; n > 0 {
+		fmt.Fprintf(&b, " (and %d more failing cases)", n)
+	}
+	return errors.New(b.String())
+}
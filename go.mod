@@ -2,4 +2,10 @@ module first-golang
 
 go 1.25.4
 
-require golang.org/x/tour v0.1.0
+require (
+	golang.org/x/net v0.57.0
+	golang.org/x/term v0.45.0
+	golang.org/x/tour v0.1.0
+)
+
+require golang.org/x/sys v0.47.0 // indirect
@@ -0,0 +1,122 @@
+// Package golden compares the output of lessons with checked-in golden
+// files under testdata/golden, one <code>.golden file per lesson.
+package golden
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"first-golang/diff"
+	"first-golang/registry"
+	"first-golang/runner"
+)
+
+// Dir is the golden file directory, relative to the module root.
+const Dir = "testdata/golden"
+
+// Status is the outcome of checking one lesson.
+type Status int
+
+const (
+	StatusPass Status = iota
+	StatusFail
+	StatusMissing // no golden file yet
+	StatusUpdated
+	StatusSkipped
+	StatusError // the lesson timed out
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusPass:
+		return "ok"
+	case StatusFail:
+		return "FAIL"
+	case StatusMissing:
+		return "missing"
+	case StatusUpdated:
+		return "updated"
+	case StatusSkipped:
+		return "skip"
+	case StatusError:
+		return "ERROR"
+	default:
+		return "unknown"
+	}
+}
+
+// Outcome is the result of checking one lesson.
+type Outcome struct {
+	Lesson registry.Lesson
+	Status Status
+	Diff   string // golden vs. actual output, for StatusFail
+	Reason string // why the lesson was skipped or errored
+}
+
+// Failed reports whether the outcome should fail a check run.
+func (o Outcome) Failed() bool {
+	switch o.Status {
+	case StatusFail, StatusMissing, StatusError:
+		return true
+	}
+	return false
+}
+
+// Path returns the golden file of a lesson.
+func Path(l registry.Lesson) string {
+	return filepath.Join(registry.Root(), Dir, l.Code+".golden")
+}
+
+// Check runs the lesson, masks the nondeterministic parts of its output
+// and compares the result with the lesson's golden file. With update set,
+// the golden file is rewritten instead.
+func Check(l registry.Lesson, opts runner.Options, update bool) (Outcome, error) {
+	o := Outcome{Lesson: l}
+	if reason, skip := skipped[l.Code]; skip {
+		o.Status, o.Reason = StatusSkipped, reason
+		return o, nil
+	}
+
+	r, out := runner.Capture(l, opts)
+	switch r.Status {
+	case runner.StatusPanicked:
+		// Some lessons panic on purpose (05-13, 05-15), so the panic
+		// value is part of the expected output; the stack is not.
+		out = fmt.Appendf(out, "panic: %v\n", r.Panic)
+	case runner.StatusTimedOut:
+		o.Status, o.Reason = StatusError, r.Status.String()
+		return o, nil
+	}
+	got := Mask(l.Code, string(out))
+
+	path := Path(l)
+	if update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return o, err
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			return o, err
+		}
+		o.Status = StatusUpdated
+		return o, nil
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		o.Status = StatusMissing
+		return o, nil
+	}
+	if err != nil {
+		return o, err
+	}
+	o.Diff = diff.Unified(l.Code+".golden", l.Code+" output", string(want), got)
+	if o.Diff == "" {
+		o.Status = StatusPass
+	} else {
+		o.Status = StatusFail
+	}
+	return o, nil
+}
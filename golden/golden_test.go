@@ -0,0 +1,50 @@
+package golden_test
+
+import (
+	"flag"
+	"testing"
+
+	_ "first-golang/01-welcome"
+	_ "first-golang/02-basics"
+	_ "first-golang/03-flow-control"
+	_ "first-golang/04-more-types"
+	_ "first-golang/05-methods"
+	_ "first-golang/06-generics"
+	_ "first-golang/07-concurrency"
+	"first-golang/golden"
+	"first-golang/i18n"
+	"first-golang/registry"
+	"first-golang/runner"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files instead of comparing")
+
+// TestGolden compares the output of every lesson with its golden file:
+//
+//	go test ./golden
+//	go test ./golden -update
+func TestGolden(t *testing.T) {
+	// The golden files record the Chinese narration, whatever the
+	// language of the environment.
+	defer i18n.Set(i18n.Current())
+	i18n.Set(i18n.ZhTW)
+	opts := runner.Options{Timeout: runner.DefaultTimeout}
+	for _, l := range registry.All() {
+		t.Run(l.Code, func(t *testing.T) {
+			o, err := golden.Check(l, opts, *update)
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch o.Status {
+			case golden.StatusSkipped:
+				t.Skip(o.Reason)
+			case golden.StatusMissing:
+				t.Fatalf("no %s; run with -update to create it", golden.Path(l))
+			case golden.StatusFail:
+				t.Errorf("output differs from the golden file:\n%s", o.Diff)
+			case golden.StatusError:
+				t.Errorf("%s: %s", l.Title, o.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package golden
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// skipped lists lessons whose output cannot be pinned down at all.
+var skipped = map[string]string{
+	"03-04": "never returns",
+}
+
+// A mask rewrites the nondeterministic parts of a lesson's output into a
+// stable form.
+type mask func(out string) string
+
+// masks lists the lessons with nondeterministic output and how to tame it.
+var masks = map[string][]mask{
+	// time.Now
+	"01-04": {replace(timestamp, "<time>")},
+	// rand.Intn
+	"02-01": {replace(regexp.MustCompile(`is \d+`), "is <n>")},
+	// runtime.GOOS
+	"03-09": {replace(regexp.MustCompile(`(?m)^Go runs on .*$`), "Go runs on <os>.")},
+	// today's weekday
+	"03-10": {replace(regexp.MustCompile(`(?m)^(Today|Tomorrow|In two days|Too far away)\.$`), "<when>.")},
+	// time of day
+	"03-11": {replace(regexp.MustCompile(`(?m)^Good (morning!|afternoon\.|evening\.)$`), "Good <time of day>")},
+	// map iteration order
+	"05-18": {sortLines(regexp.MustCompile(`^\w+: \d+\.\d+\.\d+\.\d+$`))},
+	// MyError.When
+	"05-19": {replace(timestamp, "<time>")},
+	// two goroutines printing concurrently
+	"07-01": {sortLines(regexp.MustCompile(`^(hello|world)$`))},
+	// which of the two sum goroutines finishes first
+	"07-02": {sortFields(regexp.MustCompile(`^-?\d+ -?\d+ -?\d+$`), 2)},
+	// select picks a ready case at random
+	"07-05": {replace(regexp.MustCompile(`來自 ch\d`), "來自 ch<n>")},
+	// ticks and dots depend on scheduling; only the final BOOM is stable
+	"07-06": {
+		replace(regexp.MustCompile(`(?m)^\[[^\]]*\]\s+(\.|tick\.)\n`), ""),
+		replace(regexp.MustCompile(`(?m)^\[[^\]]*\] BOOM!`), "[<elapsed>] BOOM!"),
+	},
+	// crawl goroutines finish in any order
+	"07-10": {sortLines(regexp.MustCompile(`^(found: |not found: )`))},
+}
+
+// timestamp matches time.Time's default String form, including the
+// monotonic clock reading.
+var timestamp = regexp.MustCompile(`\d{4}-\d\d-\d\d \d\d:\d\d:\d\d(\.\d+)? [+-]\d{4} \w+( m=[+-]\d+\.\d+)?`)
+
+// Mask applies the masks registered for the lesson code to its output.
+func Mask(code, out string) string {
+	for _, m := range masks[code] {
+		out = m(out)
+	}
+	return out
+}
+
+func replace(re *regexp.Regexp, repl string) mask {
+	return func(out string) string {
+		return re.ReplaceAllLiteralString(out, repl)
+	}
+}
+
+// sortLines sorts the lines matching re among themselves, leaving every
+// other line where it is.
+func sortLines(re *regexp.Regexp) mask {
+	return func(out string) string {
+		lines := strings.Split(out, "\n")
+		var idx []int
+		var matched []string
+		for i, line := range lines {
+			if re.MatchString(line) {
+				idx = append(idx, i)
+				matched = append(matched, line)
+			}
+		}
+		slices.Sort(matched)
+		for k, i := range idx {
+			lines[i] = matched[k]
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// sortFields sorts the first n space-separated fields of each line
+// matching re.
+func sortFields(re *regexp.Regexp, n int) mask {
+	return func(out string) string {
+		lines := strings.Split(out, "\n")
+		for i, line := range lines {
+			if !re.MatchString(line) {
+				continue
+			}
+			fields := strings.Fields(line)
+			slices.Sort(fields[:n])
+			lines[i] = strings.Join(fields, " ")
+		}
+		return strings.Join(lines, "\n")
+	}
+}
@@ -0,0 +1,289 @@
+// Package highlight prints Go source for the terminal: with line numbers,
+// ANSI syntax colors, and the Chinese study notes in the comments either
+// shown, stripped or shown on their own.
+package highlight
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// CommentMode selects which comments are printed.
+type CommentMode int
+
+const (
+	AllComments CommentMode = iota
+	StripNotes              // drop comments written in Chinese
+	OnlyNotes               // print only the comments written in Chinese
+)
+
+// ParseCommentMode parses "all", "strip" or "only".
+func ParseCommentMode(s string) (CommentMode, error) {
+	switch s {
+	case "all":
+		return AllComments, nil
+	case "strip":
+		return StripNotes, nil
+	case "only":
+		return OnlyNotes, nil
+	}
+	return 0, fmt.Errorf("unknown comment mode %q (want all, strip or only)", s)
+}
+
+// Options controls Render.
+type Options struct {
+	LineNumbers bool
+	Color       bool
+	Comments    CommentMode
+}
+
+// ANSI SGR sequences for each kind of span.
+const (
+	reset       = "\x1b[0m"
+	colorKey    = "\x1b[35m" // keywords
+	colorString = "\x1b[32m"
+	colorNumber = "\x1b[36m"
+	colorNote   = "\x1b[33m" // Chinese notes
+	colorCmt    = "\x1b[90m" // other comments
+	colorLineNo = "\x1b[2m"
+)
+
+type kind int
+
+const (
+	plain kind = iota
+	keyword
+	str
+	number
+	comment
+	note // a comment containing Chinese
+)
+
+func (k kind) color() string {
+	switch k {
+	case keyword:
+		return colorKey
+	case str:
+		return colorString
+	case number:
+		return colorNumber
+	case comment:
+		return colorCmt
+	case note:
+		return colorNote
+	}
+	return ""
+}
+
+// span is a classified byte range of the source.
+type span struct {
+	start, end int
+	kind       kind
+}
+
+// IsNote reports whether a comment is one of the Chinese study notes.
+func IsNote(comment string) bool {
+	for _, r := range comment {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// classify scans src into spans. Bytes not covered by a span are plain.
+func classify(src []byte) []span {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, src, nil, scanner.ScanComments)
+
+	var spans []span
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		off := file.Offset(pos)
+		var k kind
+		switch {
+		case tok == token.COMMENT:
+			k = comment
+			if IsNote(lit) {
+				k = note
+			}
+		case tok.IsKeyword():
+			k = keyword
+		case tok == token.STRING || tok == token.CHAR:
+			k = str
+		case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+			k = number
+		default:
+			continue
+		}
+		if lit == "" {
+			continue
+		}
+		spans = append(spans, span{off, off + len(lit), k})
+	}
+	markNoteGroups(src, spans)
+	return spans
+}
+
+// markNoteGroups marks every comment of a comment group as a note when
+// any of them is one, so that the bare "//" lines separating the
+// paragraphs of a note go with it. As in go/ast, a group is a run of
+// comments with nothing but white space and at most one newline between
+// them.
+func markNoteGroups(src []byte, spans []span) {
+	for i := 0; i < len(spans); {
+		if spans[i].kind != comment && spans[i].kind != note {
+			i++
+			continue
+		}
+		j, hasNote := i, spans[i].kind == note
+		for j+1 < len(spans) && (spans[j+1].kind == comment || spans[j+1].kind == note) {
+			gap := string(src[spans[j].end:spans[j+1].start])
+			if strings.TrimSpace(gap) != "" || strings.Count(gap, "\n") > 1 {
+				break
+			}
+			j++
+			hasNote = hasNote || spans[j].kind == note
+		}
+		if hasNote {
+			for k := i; k <= j; k++ {
+				spans[k].kind = note
+			}
+		}
+		i = j + 1
+	}
+}
+
+// Render writes src to w as configured by opts.
+func Render(w io.Writer, src []byte, opts Options) error {
+	spans := classify(src)
+	keep := func(k kind) bool {
+		switch opts.Comments {
+		case StripNotes:
+			return k != note
+		case OnlyNotes:
+			return k == note
+		}
+		return true
+	}
+
+	lines := strings.SplitAfter(string(src), "\n")
+	if n := len(lines); n > 0 && lines[n-1] == "" {
+		lines = lines[:n-1]
+	}
+	width := len(fmt.Sprint(len(lines)))
+
+	si := 0 // first span that may touch the current line
+	lineStart := 0
+	lastPrinted := -1
+	lastBlank := false
+	for i, line := range lines {
+		lineEnd := lineStart + len(line)
+		text := strings.TrimSuffix(line, "\n")
+
+		var sb strings.Builder
+		hadContent := strings.TrimSpace(text) != ""
+		hasNote := false
+		pos := lineStart
+		for j := si; j < len(spans) && spans[j].start < lineEnd; j++ {
+			sp := spans[j]
+			if sp.end <= lineStart {
+				continue
+			}
+			from, to := max(sp.start, lineStart), min(sp.end, lineStart+len(text))
+			if sp.kind == note {
+				hasNote = true
+			}
+			if opts.Comments != OnlyNotes {
+				sb.WriteString(src2str(src, pos, from))
+			}
+			if keep(sp.kind) {
+				writeColored(&sb, src2str(src, from, to), sp.kind, opts.Color)
+			}
+			pos = to
+		}
+		if opts.Comments != OnlyNotes {
+			sb.WriteString(src2str(src, pos, lineStart+len(text)))
+		}
+		for si < len(spans) && spans[si].end <= lineEnd {
+			si++
+		}
+		lineStart = lineEnd
+
+		out := sb.String()
+		switch opts.Comments {
+		case StripNotes:
+			if hadContent && strings.TrimSpace(stripANSI(out)) == "" {
+				continue // the line held nothing but a note
+			}
+			out = strings.TrimRight(out, " \t")
+			if out == "" && lastBlank {
+				continue // a dropped note between two blank lines
+			}
+			lastBlank = out == ""
+		case OnlyNotes:
+			if !hasNote {
+				continue
+			}
+			if lastPrinted >= 0 && i != lastPrinted+1 {
+				fmt.Fprintln(w) // separate notes that are not adjacent
+			}
+		}
+		lastPrinted = i
+
+		if opts.LineNumbers {
+			num := fmt.Sprintf("%*d", width, i+1)
+			if opts.Color {
+				num = colorLineNo + num + reset
+			}
+			if _, err := fmt.Fprintf(w, "%s  ", num); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func src2str(src []byte, from, to int) string {
+	if from >= to {
+		return ""
+	}
+	return string(src[from:to])
+}
+
+func writeColored(sb *strings.Builder, s string, k kind, color bool) {
+	c := k.color()
+	if !color || c == "" {
+		sb.WriteString(s)
+		return
+	}
+	sb.WriteString(c)
+	sb.WriteString(s)
+	sb.WriteString(reset)
+}
+
+func stripANSI(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0x1b {
+			for i < len(s) && s[i] != 'm' {
+				i++
+			}
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
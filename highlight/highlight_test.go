@@ -0,0 +1,95 @@
+package highlight
+
+import (
+	"strings"
+	"testing"
+)
+
+const src = `package p
+
+// 這是筆記
+//
+// 第二段筆記
+func f() int {
+	return 42 // 回答
+}
+
+// f2 is not a note
+func f2() string { return "s" }
+`
+
+func render(t *testing.T, opts Options) string {
+	t.Helper()
+	var b strings.Builder
+	if err := Render(&b, []byte(src), opts); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestRenderComments(t *testing.T) {
+	tests := []struct {
+		mode CommentMode
+		want string
+	}{
+		{AllComments, src},
+		{StripNotes, `package p
+
+func f() int {
+	return 42
+}
+
+// f2 is not a note
+func f2() string { return "s" }
+`},
+		{OnlyNotes, `// 這是筆記
+//
+// 第二段筆記
+
+// 回答
+`},
+	}
+	for _, tt := range tests {
+		if got := render(t, Options{Comments: tt.mode}); got != tt.want {
+			t.Errorf("mode %d:\n%s\nwant\n%s", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestRenderLineNumbers(t *testing.T) {
+	got := render(t, Options{LineNumbers: true, Comments: OnlyNotes})
+	want := " 3  // 這是筆記\n 4  //\n 5  // 第二段筆記\n\n 7  // 回答\n"
+	if got != want {
+		t.Errorf("got\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRenderColor(t *testing.T) {
+	got := render(t, Options{Color: true})
+	for _, s := range []string{
+		colorKey + "package" + reset,
+		colorNumber + "42" + reset,
+		colorString + `"s"` + reset,
+		colorNote + "// 這是筆記" + reset,
+		colorNote + "//" + reset, // the bare line of a note goes with it
+		colorCmt + "// f2 is not a note" + reset,
+	} {
+		if !strings.Contains(got, s) {
+			t.Errorf("colored output lacks %q", s)
+		}
+	}
+	if stripANSI(got) != src {
+		t.Errorf("colors changed the text:\n%s", stripANSI(got))
+	}
+}
+
+func TestParseCommentMode(t *testing.T) {
+	for s, want := range map[string]CommentMode{"all": AllComments, "strip": StripNotes, "only": OnlyNotes} {
+		if got, err := ParseCommentMode(s); err != nil || got != want {
+			t.Errorf("ParseCommentMode(%q) = %v, %v", s, got, err)
+		}
+	}
+	if _, err := ParseCommentMode("none"); err == nil {
+		t.Errorf("ParseCommentMode(none) succeeded")
+	}
+}
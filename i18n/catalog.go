@@ -0,0 +1,474 @@
+package i18n
+
+// catalog holds every message, by key, in each language. Messages
+// that are format strings are used with Sprintf and Fprintf.
+var catalog = map[string]message{
+	// 04-18
+	"04-18.first-rows": {
+		zhTW: "Pic(5, 5) 的前幾行:",
+		en:   "First rows of Pic(5, 5):",
+	},
+	"04-18.row": {
+		zhTW: "  行 %d: %v\n",
+		en:   "  row %d: %v\n",
+	},
+	// 05-20
+	"05-20.intro": {
+		zhTW: "測試 Sqrt 函數的錯誤處理",
+		en:   "Testing how Sqrt handles errors",
+	},
+	"05-20.error": {
+		zhTW: "Sqrt(%v) = 錯誤: %v\n",
+		en:   "Sqrt(%v) = error: %v\n",
+	},
+	"05-20.notes": {
+		zhTW: "=== 說明 ===",
+		en:   "=== Notes ===",
+	},
+	"05-20.note1": {
+		zhTW: "1. ErrNegativeSqrt 實作 error 介面",
+		en:   "1. ErrNegativeSqrt implements the error interface",
+	},
+	"05-20.note2": {
+		zhTW: "2. Error() 方法中必須轉換為 float64 避免無限循環",
+		en:   "2. Error() must convert to float64 to avoid infinite recursion",
+	},
+	"05-20.note3": {
+		zhTW: "3. Sqrt 函數返回 (float64, error) 兩個值",
+		en:   "3. Sqrt returns two values, (float64, error)",
+	},
+	"05-20.note4": {
+		zhTW: "4. 負數輸入時返回 ErrNegativeSqrt 錯誤",
+		en:   "4. A negative input returns an ErrNegativeSqrt error",
+	},
+	"05-20.note5": {
+		zhTW: "5. 正常情況返回結果和 nil error",
+		en:   "5. Otherwise it returns the result and a nil error",
+	},
+	// 05-23
+	"05-23.intro": {
+		zhTW: "以下輸出應該是解碼後的字串：",
+		en:   "The output below should be the decoded string:",
+	},
+	// 06-02
+	"06-02.int-list": {
+		zhTW: "=== 整數鏈表 ===",
+		en:   "=== List of ints ===",
+	},
+	"06-02.length": {
+		zhTW: "長度: %d\n",
+		en:   "Length: %d\n",
+	},
+	"06-02.index2": {
+		zhTW: "索引 2 的值: %d\n",
+		en:   "Value at index 2: %d\n",
+	},
+	"06-02.contains3": {
+		zhTW: "包含 3: %v\n",
+		en:   "Contains 3: %v\n",
+	},
+	"06-02.contains10": {
+		zhTW: "包含 10: %v\n",
+		en:   "Contains 10: %v\n",
+	},
+	"06-02.string-list": {
+		zhTW: "=== 字符串鏈表 ===",
+		en:   "=== List of strings ===",
+	},
+	// 07-03
+	"07-03.example1": {
+		zhTW: "=== 示例 1: 正常使用緩衝通道 ===",
+		en:   "=== Example 1: using a buffered channel ===",
+	},
+	"07-03.sent-two": {
+		zhTW: "已發送 2 個值到緩衝通道（緩衝區大小為 2）",
+		en:   "Sent 2 values to the buffered channel (buffer size 2)",
+	},
+	"07-03.received": {
+		zhTW: "接收值:",
+		en:   "Received:",
+	},
+	"07-03.example2": {
+		zhTW: "=== 示例 2: 緩衝區溢出（會導致死鎖） ===",
+		en:   "=== Example 2: overfilling the buffer (deadlocks) ===",
+	},
+	"07-03.buffer-full": {
+		zhTW: "已發送 2 個值，緩衝區已滿",
+		en:   "Sent 2 values; the buffer is full",
+	},
+	"07-03.send-third-blocks": {
+		zhTW: "嘗試發送第三個值（會阻塞，因為緩衝區已滿）...",
+		en:   "Sending a third value (blocks, because the buffer is full)...",
+	},
+	"07-03.receive-first": {
+		zhTW: "先接收一個值:",
+		en:   "Receive one value first:",
+	},
+	"07-03.sent-third": {
+		zhTW: "成功發送第三個值",
+		en:   "Sent the third value",
+	},
+	"07-03.example3": {
+		zhTW: "=== 示例 3: 緩衝區為空時接收會阻塞 ===",
+		en:   "=== Example 3: receiving from an empty buffer blocks ===",
+	},
+	"07-03.empty-blocks": {
+		zhTW: "緩衝區為空，嘗試接收會阻塞...",
+		en:   "The buffer is empty; receiving would block...",
+	},
+	"07-03.sent-10": {
+		zhTW: "已發送值 10",
+		en:   "Sent the value 10",
+	},
+	"07-03.summary": {
+		zhTW: "=== 總結 ===",
+		en:   "=== Summary ===",
+	},
+	"07-03.behavior": {
+		zhTW: "緩衝通道的行為：",
+		en:   "How a buffered channel behaves:",
+	},
+	"07-03.behavior-send": {
+		zhTW: "  - 發送：只有在緩衝區滿時才阻塞",
+		en:   "  - send: blocks only when the buffer is full",
+	},
+	"07-03.behavior-receive": {
+		zhTW: "  - 接收：只有在緩衝區空時才阻塞",
+		en:   "  - receive: blocks only when the buffer is empty",
+	},
+	"07-03.behavior-deadlock": {
+		zhTW: "  - 死鎖：當所有 goroutine 都在等待時發生（發送者等待空間，接收者等待數據）",
+		en:   "  - deadlock: happens when every goroutine is waiting (senders for room, receivers for data)",
+	},
+	"07-03.deadlock-demo": {
+		zhTW: "=== 演示：緩衝區溢出導致死鎖 ===",
+		en:   "=== Demo: overfilling the buffer deadlocks ===",
+	},
+	"07-03.deadlock-full": {
+		zhTW: "已發送 2 個值，緩衝區已滿（大小為 2）",
+		en:   "Sent 2 values; the buffer is full (size 2)",
+	},
+	"07-03.deadlock-send": {
+		zhTW: "嘗試發送第三個值（會導致死鎖）...",
+		en:   "Sending a third value (deadlocks)...",
+	},
+	"07-03.deadlock-never": {
+		zhTW: "這行永遠不會執行",
+		en:   "This line is never reached",
+	},
+	// 07-04
+	"07-04.example1": {
+		zhTW: "=== 示例 1: 使用 Range 循環接收值 ===",
+		en:   "=== Example 1: receiving values with range ===",
+	},
+	"07-04.example2": {
+		zhTW: "=== 示例 2: 手動檢測 Channel 是否關閉 ===",
+		en:   "=== Example 2: checking by hand whether a channel is closed ===",
+	},
+	"07-04.closed": {
+		zhTW: "Channel 已關閉，沒有更多值",
+		en:   "The channel is closed; no more values",
+	},
+	"07-04.received": {
+		zhTW: "接收值:",
+		en:   "Received:",
+	},
+	"07-04.example3": {
+		zhTW: "=== 示例 3: 從已關閉的 Channel 接收 ===",
+		en:   "=== Example 3: receiving from a closed channel ===",
+	},
+	"07-04.value-ok": {
+		zhTW: "值: %d, 是否還有值: %v\n",
+		en:   "value: %d, ok: %v\n",
+	},
+	"07-04.example4": {
+		zhTW: "=== 示例 4: 在已關閉的 Channel 上發送會導致 Panic ===",
+		en:   "=== Example 4: sending on a closed channel panics ===",
+	},
+	"07-04.send-panics": {
+		zhTW: "注意：在已關閉的 channel 上發送會導致 panic",
+		en:   "Note: sending on a closed channel causes a panic",
+	},
+	"07-04.summary": {
+		zhTW: "=== 總結 ===",
+		en:   "=== Summary ===",
+	},
+	"07-04.summary1": {
+		zhTW: "1. 只有發送者應該關閉 channel",
+		en:   "1. Only the sender should close a channel",
+	},
+	"07-04.summary2": {
+		zhTW: "2. 使用 close(ch) 關閉 channel",
+		en:   "2. Close a channel with close(ch)",
+	},
+	"07-04.summary3": {
+		zhTW: "3. 使用 v, ok := <-ch 檢測 channel 是否關閉",
+		en:   "3. Use v, ok := <-ch to tell whether a channel is closed",
+	},
+	"07-04.summary4": {
+		zhTW: "4. 使用 for i := range c 循環接收值直到 channel 關閉",
+		en:   "4. for i := range c receives values until the channel is closed",
+	},
+	"07-04.summary5": {
+		zhTW: "5. 在已關閉的 channel 上發送會導致 panic",
+		en:   "5. Sending on a closed channel causes a panic",
+	},
+	"07-04.summary6": {
+		zhTW: "6. 從已關閉的 channel 接收會返回零值和 false",
+		en:   "6. Receiving from a closed channel returns the zero value and false",
+	},
+	// 07-05
+	"07-05.example1": {
+		zhTW: "=== 示例 1: 使用 Select 處理多個 Channel ===",
+		en:   "=== Example 1: handling several channels with select ===",
+	},
+	"07-05.example2": {
+		zhTW: "=== 示例 2: Select 的隨機選擇行為 ===",
+		en:   "=== Example 2: select picks at random ===",
+	},
+	"07-05.from-ch1": {
+		zhTW: "來自 ch1",
+		en:   "from ch1",
+	},
+	"07-05.from-ch2": {
+		zhTW: "來自 ch2",
+		en:   "from ch2",
+	},
+	"07-05.received": {
+		zhTW: "接收到:",
+		en:   "Received:",
+	},
+	"07-05.example3": {
+		zhTW: "=== 示例 3: Select 與 Default（非阻塞） ===",
+		en:   "=== Example 3: select with default (non-blocking) ===",
+	},
+	"07-05.sent": {
+		zhTW: "發送成功",
+		en:   "Sent",
+	},
+	"07-05.got": {
+		zhTW: "接收成功",
+		en:   "Received",
+	},
+	"07-05.not-ready": {
+		zhTW: "Channel 未準備好，執行 default",
+		en:   "The channel is not ready; running default",
+	},
+	"07-05.example4": {
+		zhTW: "=== 示例 4: Select 與超時 ===",
+		en:   "=== Example 4: select with a timeout ===",
+	},
+	"07-05.data": {
+		zhTW: "數據",
+		en:   "data",
+	},
+	"07-05.timeout": {
+		zhTW: "超時：1 秒內未收到數據",
+		en:   "Timeout: no data within 1 second",
+	},
+	"07-05.summary": {
+		zhTW: "=== 總結 ===",
+		en:   "=== Summary ===",
+	},
+	"07-05.summary1": {
+		zhTW: "1. select 允許等待多個 channel 操作",
+		en:   "1. select waits on several channel operations",
+	},
+	"07-05.summary2": {
+		zhTW: "2. select 會阻塞直到一個 case 可以執行",
+		en:   "2. select blocks until one of its cases can run",
+	},
+	"07-05.summary3": {
+		zhTW: "3. 如果多個 case 都準備好，會隨機選擇一個",
+		en:   "3. If several cases are ready, it picks one at random",
+	},
+	"07-05.summary4": {
+		zhTW: "4. 使用 default 可以實現非阻塞操作",
+		en:   "4. A default case makes the operations non-blocking",
+	},
+	"07-05.summary5": {
+		zhTW: "5. 常用於實現超時、取消和優先級處理",
+		en:   "5. It is the usual way to implement timeouts, cancellation and priorities",
+	},
+	// 07-06
+	"07-06.example": {
+		zhTW: "=== 示例：使用 Default Case 實現非阻塞操作 ===",
+		en:   "=== Example: non-blocking operations with a default case ===",
+	},
+	"07-06.intro-tick": {
+		zhTW: "每 100ms 會收到 tick，500ms 後會收到 boom",
+		en:   "A tick arrives every 100ms, and a boom after 500ms",
+	},
+	"07-06.intro-default": {
+		zhTW: "在沒有事件時，default case 會執行並打印 '.'",
+		en:   "When nothing happens, the default case runs and prints '.'",
+	},
+	"07-06.simple": {
+		zhTW: "=== 簡單示例：非阻塞的 Channel 操作 ===",
+		en:   "=== Simple example: non-blocking channel operations ===",
+	},
+	"07-06.received": {
+		zhTW: "接收到值:",
+		en:   "Received:",
+	},
+	"07-06.empty": {
+		zhTW: "Channel 為空，接收會阻塞，執行 default",
+		en:   "The channel is empty; receiving would block, running default",
+	},
+	"07-06.sent": {
+		zhTW: "發送成功",
+		en:   "Sent",
+	},
+	"07-06.not-ready": {
+		zhTW: "Channel 未準備好，發送會阻塞，執行 default",
+		en:   "The channel is not ready; sending would block, running default",
+	},
+	"07-06.contrast": {
+		zhTW: "對比：沒有 default 的 select 會阻塞",
+		en:   "By contrast, a select without default blocks",
+	},
+	// 07-07
+	"07-07.test-walk": {
+		zhTW: "=== 測試 Walk 函數 ===",
+		en:   "=== Testing Walk ===",
+	},
+	"07-07.walk-result": {
+		zhTW: "Walk 結果: ",
+		en:   "Walk: ",
+	},
+	"07-07.test-same": {
+		zhTW: "=== 測試 Same 函數 ===",
+		en:   "=== Testing Same ===",
+	},
+	"07-07.same-1-1": {
+		zhTW: "Same(tree.New(1), tree.New(1)) = %v (期望: true)\n",
+		en:   "Same(tree.New(1), tree.New(1)) = %v (want true)\n",
+	},
+	"07-07.same-1-2": {
+		zhTW: "Same(tree.New(1), tree.New(2)) = %v (期望: false)\n",
+		en:   "Same(tree.New(1), tree.New(2)) = %v (want false)\n",
+	},
+	"07-07.extra": {
+		zhTW: "=== 額外測試 ===",
+		en:   "=== Extra test ===",
+	},
+	"07-07.same-shapes": {
+		zhTW: "Same(兩棵結構不同但值相同的樹) = %v (期望: true)\n",
+		en:   "Same(two differently shaped trees with the same values) = %v (want true)\n",
+	},
+	"07-07.notes": {
+		zhTW: "=== 實現說明 ===",
+		en:   "=== How it works ===",
+	},
+	"07-07.note1": {
+		zhTW: "1. Walk 函數使用中序遍歷（左-根-右）來按順序發送值",
+		en:   "1. Walk traverses in order (left, root, right) to send the values sorted",
+	},
+	"07-07.note2": {
+		zhTW: "2. Same 函數同時遍歷兩棵樹，逐個比較值",
+		en:   "2. Same walks both trees at once and compares the values one by one",
+	},
+	"07-07.note3": {
+		zhTW: "3. 使用 goroutine 和 channel 實現並發遍歷和比較",
+		en:   "3. Goroutines and channels make the walks and the comparison concurrent",
+	},
+	"07-07.note4": {
+		zhTW: "4. 如果值序列完全相同，返回 true；否則返回 false",
+		en:   "4. It returns true if the sequences of values are identical, false otherwise",
+	},
+	// 07-09
+	"07-09.example": {
+		zhTW: "=== 示例：使用 Mutex 保護共享數據 ===",
+		en:   "=== Example: protecting shared data with a Mutex ===",
+	},
+	"07-09.intro": {
+		zhTW: "啟動 1000 個 goroutine 同時遞增計數器",
+		en:   "Starting 1000 goroutines that increment the counter at the same time",
+	},
+	"07-09.final-count": {
+		zhTW: "最終計數值: %d (期望: 1000)\n",
+		en:   "Final count: %d (want 1000)\n",
+	},
+	"07-09.without": {
+		zhTW: "=== 對比：沒有 Mutex 的情況 ===",
+		en:   "=== By contrast: without a Mutex ===",
+	},
+	"07-09.without-intro": {
+		zhTW: "如果沒有互斥鎖保護，多個 goroutine 同時修改 map 會導致：",
+		en:   "Without a lock, goroutines modifying the map at the same time cause:",
+	},
+	"07-09.without1": {
+		zhTW: "1. 數據競爭（data race）",
+		en:   "1. a data race",
+	},
+	"07-09.without2": {
+		zhTW: "2. 不確定的結果（可能少於 1000）",
+		en:   "2. unpredictable results (possibly fewer than 1000)",
+	},
+	"07-09.without3": {
+		zhTW: "3. 程序可能崩潰或產生錯誤的數據",
+		en:   "3. a crash, or corrupted data",
+	},
+	"07-09.tips": {
+		zhTW: "=== Mutex 使用要點 ===",
+		en:   "=== Using a Mutex ===",
+	},
+	"07-09.tip1": {
+		zhTW: "1. 在訪問共享資源前調用 Lock()",
+		en:   "1. Call Lock() before touching the shared data",
+	},
+	"07-09.tip2": {
+		zhTW: "2. 訪問完成後調用 Unlock()",
+		en:   "2. Call Unlock() when done",
+	},
+	"07-09.tip3": {
+		zhTW: "3. 使用 defer Unlock() 可以確保鎖一定會被釋放",
+		en:   "3. defer Unlock() makes sure the lock is always released",
+	},
+	"07-09.tip4": {
+		zhTW: "4. 鎖的持有時間應該盡可能短，避免影響並發性能",
+		en:   "4. Hold the lock as briefly as possible, so goroutines are not held up",
+	},
+	"07-09.tip5": {
+		zhTW: "5. 不要忘記解鎖，否則會導致死鎖",
+		en:   "5. Never forget to unlock, or the program deadlocks",
+	},
+	// 07-10
+	"07-10.example": {
+		zhTW: "=== 並行網頁爬蟲示例 ===",
+		en:   "=== Concurrent web crawler ===",
+	},
+	"07-10.intro": {
+		zhTW: "使用 goroutine 並行獲取 URL，並使用 mutex 保護 URL 緩存",
+		en:   "Fetches URLs in parallel with goroutines, with a mutex guarding the URL cache",
+	},
+	"07-10.notes": {
+		zhTW: "=== 實現說明 ===",
+		en:   "=== How it works ===",
+	},
+	"07-10.note1": {
+		zhTW: "1. 使用 sync.Mutex 保護 URL 緩存 map，確保並發安全",
+		en:   "1. A sync.Mutex guards the URL cache map, making it safe for concurrent use",
+	},
+	"07-10.note2": {
+		zhTW: "2. 在獲取 URL 前檢查緩存，避免重複獲取",
+		en:   "2. The cache is checked before fetching, so no URL is fetched twice",
+	},
+	"07-10.note3": {
+		zhTW: "3. 使用 goroutine 並行處理所有子 URL",
+		en:   "3. Each child URL is crawled in a goroutine of its own",
+	},
+	"07-10.note4": {
+		zhTW: "4. 使用 sync.WaitGroup 等待所有 goroutine 完成",
+		en:   "4. A sync.WaitGroup waits for every goroutine to finish",
+	},
+	"07-10.note5": {
+		zhTW: "5. 遞歸深度控制確保不會無限遞歸",
+		en:   "5. The depth limit keeps the recursion finite",
+	},
+}
+
+// message is one message in every language.
+type message struct {
+	zhTW, en string
+}
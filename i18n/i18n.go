@@ -0,0 +1,131 @@
+// Package i18n lets the lessons narrate in Traditional Chinese or in
+// English. Narration is not written into the lessons; they print messages
+// by key from a catalog that has every message in both languages:
+//
+//	i18n.Fprintln(w, "07-03.sent-two")
+//	i18n.Fprintln(w, "07-03.received", <-ch)
+//	i18n.Fprintf(w, "07-09.final-count", c.Value("somekey"))
+//
+// Keys are "<lesson code>.<name>". The language is chosen once for the
+// whole program with Set, before lessons run; Chinese is the default.
+package i18n
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Lang is a language the lessons can narrate in.
+type Lang string
+
+const (
+	ZhTW Lang = "zh-TW" // Traditional Chinese, the language the lessons were written in
+	En   Lang = "en"
+)
+
+// Langs lists the supported languages, the default first.
+var Langs = []Lang{ZhTW, En}
+
+// Parse returns the language named by s, which may be a bare tag ("en",
+// "zh-TW") or a locale as found in $LANG ("en_US.UTF-8", "zh_TW.UTF-8").
+// Any Chinese locale selects ZhTW and any English one En.
+func Parse(s string) (Lang, error) {
+	tag, _, _ := strings.Cut(s, ".") // drop the encoding
+	tag, _, _ = strings.Cut(tag, "@")
+	base, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(tag, "_", "-")), "-")
+	switch base {
+	case "zh":
+		return ZhTW, nil
+	case "en":
+		return En, nil
+	}
+	return "", fmt.Errorf("unsupported language %q (want zh-TW or en)", s)
+}
+
+// FromEnv returns the language of the user's locale, taken from LC_ALL,
+// LC_MESSAGES or LANG in that order of precedence, as C programs do. An
+// unset or unsupported locale, including "C", yields the default, ZhTW.
+func FromEnv() Lang {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+		if l, err := Parse(v); err == nil {
+			return l
+		}
+		return ZhTW
+	}
+	return ZhTW
+}
+
+var current = ZhTW
+
+// Set selects the language lessons narrate in. It is meant to be called
+// once, before any lesson runs.
+func Set(l Lang) {
+	if !slices.Contains(Langs, l) {
+		panic(fmt.Sprintf("i18n: unsupported language %q", l))
+	}
+	current = l
+}
+
+// Current returns the language selected with Set.
+func Current() Lang {
+	return current
+}
+
+// Has reports whether the catalog has a message for key.
+func Has(key string) bool {
+	_, ok := catalog[key]
+	return ok
+}
+
+// Keys returns the keys of every message in the catalog, sorted.
+func Keys() []string {
+	keys := make([]string, 0, len(catalog))
+	for k := range catalog {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Sprint returns the message for key in the current language. A message
+// missing from the catalog comes out as "%!(MISSING key)", the way fmt
+// shows a missing argument, so the gap is visible in the output.
+func Sprint(key string) string {
+	m, ok := catalog[key]
+	if !ok {
+		return "%!(MISSING " + key + ")"
+	}
+	if current == En && m.en != "" {
+		return m.en
+	}
+	return m.zhTW
+}
+
+// Sprintf formats args according to the message for key, which is a
+// format string.
+func Sprintf(key string, args ...any) string {
+	return fmt.Sprintf(Sprint(key), args...)
+}
+
+// Fprint writes the message for key to w.
+func Fprint(w io.Writer, key string) {
+	io.WriteString(w, Sprint(key))
+}
+
+// Fprintf writes Sprintf(key, args...) to w.
+func Fprintf(w io.Writer, key string, args ...any) {
+	fmt.Fprintf(w, Sprint(key), args...)
+}
+
+// Fprintln writes the message for key followed by args, separated by
+// spaces, and a newline, as fmt.Fprintln does.
+func Fprintln(w io.Writer, key string, args ...any) {
+	fmt.Fprintln(w, append([]any{Sprint(key)}, args...)...)
+}
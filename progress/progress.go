@@ -0,0 +1,216 @@
+// Package progress remembers which lessons a user has run and which
+// exercises passed their check, in a JSON file in the user's config
+// directory.
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"first-golang/registry"
+)
+
+// Record is what is known about one lesson.
+type Record struct {
+	Runs       int       `json:"runs"`
+	OKRuns     int       `json:"ok_runs"` // runs that ended with status "ok"
+	LastRun    time.Time `json:"last_run"`
+	LastStatus string    `json:"last_status"`
+
+	// Exercises only.
+	Checked   time.Time `json:"checked,omitzero"`
+	Passed    bool      `json:"passed,omitempty"`
+	LastError string    `json:"last_error,omitempty"`
+
+	// How many hints were revealed, and when the reference solution was
+	// first shown.
+	HintsSeen    int       `json:"hints_seen,omitempty"`
+	SolutionSeen time.Time `json:"solution_seen,omitzero"`
+}
+
+// State is the progress of one user.
+type State struct {
+	Lessons map[string]*Record `json:"lessons"`
+}
+
+// DefaultPath returns where the state lives unless told otherwise:
+// first-golang/progress.json under os.UserConfigDir.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "first-golang", "progress.json"), nil
+}
+
+// Load reads the state at path. A missing file is an empty state.
+func Load(path string) (*State, error) {
+	s := &State{Lessons: make(map[string]*Record)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("progress file %s: %v", path, err)
+	}
+	if s.Lessons == nil {
+		s.Lessons = make(map[string]*Record)
+	}
+	for _, r := range s.Lessons {
+		// Files written before ok_runs existed only know the last status.
+		if r.OKRuns == 0 && r.LastStatus == StatusOK {
+			r.OKRuns = 1
+		}
+	}
+	return s, nil
+}
+
+// Save writes the state to path, creating its directory if needed. The
+// file is replaced in one step, so an interrupted save leaves the old
+// state intact.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".progress-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *State) record(code string) *Record {
+	r, ok := s.Lessons[code]
+	if !ok {
+		r = &Record{}
+		s.Lessons[code] = r
+	}
+	return r
+}
+
+// StatusOK is the status of a run that ended normally, as opposed to
+// one that panicked or timed out.
+const StatusOK = "ok"
+
+// RecordRun notes that a lesson ran and how it ended.
+func (s *State) RecordRun(code, status string, at time.Time) {
+	r := s.record(code)
+	r.Runs++
+	if status == StatusOK {
+		r.OKRuns++
+	}
+	r.LastRun = at
+	r.LastStatus = status
+}
+
+// RecordCheck notes the verdict of an exercise's check; err is nil when
+// it passed. A pass is kept: failing a later attempt, for example while
+// trying another solution, does not undo it.
+func (s *State) RecordCheck(code string, err error, at time.Time) {
+	r := s.record(code)
+	r.Checked = at
+	r.LastError = ""
+	if err != nil {
+		r.LastError = err.Error()
+		return
+	}
+	r.Passed = true
+}
+
+// RecordHints notes that the first n hints of an exercise were revealed.
+// Hints stay revealed: asking for an earlier one does not hide the rest.
+func (s *State) RecordHints(code string, n int) {
+	r := s.record(code)
+	r.HintsSeen = max(r.HintsSeen, n)
+}
+
+// RecordSolution notes that the reference solution of an exercise was
+// shown, keeping the time it was first shown.
+func (s *State) RecordSolution(code string, at time.Time) {
+	r := s.record(code)
+	if r.SolutionSeen.IsZero() {
+		r.SolutionSeen = at
+	}
+}
+
+// Done reports whether a lesson counts as completed: it ran to the end
+// at least once and, for an exercise, its check passed.
+func (s *State) Done(l registry.Lesson) bool {
+	r, ok := s.Lessons[l.Code]
+	if !ok || r.OKRuns == 0 {
+		return false
+	}
+	if l.IsExercise() {
+		return r.Passed
+	}
+	return true
+}
+
+// Chapter is the completion of one chapter.
+type Chapter struct {
+	Name            string // chapter code, e.g. "04"
+	Done, Total     int
+	Exercises       int
+	ExercisesPassed int
+}
+
+// Summarize returns the completion of every chapter in lessons, in order,
+// and the lesson to do next: the first one not done after the lesson run
+// most recently, or else the first one not done at all. next is nil when
+// everything is done.
+func (s *State) Summarize(lessons []registry.Lesson) (chapters []Chapter, next *registry.Lesson) {
+	latest, first := -1, -1
+	var latestRun time.Time
+	for i, l := range lessons {
+		if n := len(chapters); n == 0 || chapters[n-1].Name != l.Chapter() {
+			chapters = append(chapters, Chapter{Name: l.Chapter()})
+		}
+		c := &chapters[len(chapters)-1]
+		c.Total++
+		done := s.Done(l)
+		if done {
+			c.Done++
+		}
+		if l.IsExercise() {
+			c.Exercises++
+			if done {
+				c.ExercisesPassed++
+			}
+		}
+		if !done && first < 0 {
+			first = i
+		}
+		if r, ok := s.Lessons[l.Code]; ok && r.LastRun.After(latestRun) {
+			latest, latestRun = i, r.LastRun
+		}
+	}
+	for i := latest + 1; i < len(lessons); i++ {
+		if !s.Done(lessons[i]) {
+			return chapters, &lessons[i]
+		}
+	}
+	if first >= 0 {
+		next = &lessons[first]
+	}
+	return chapters, next
+}
@@ -0,0 +1,110 @@
+package progress
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"first-golang/exercise"
+	"first-golang/registry"
+)
+
+var (
+	lesson         = registry.Lesson{Code: "04-18", Title: "Slices of slices"}
+	exerciseLesson = registry.Lesson{Code: "04-23", Title: "Exercise: Maps", Check: func(*exercise.T) {}}
+)
+
+func TestDone(t *testing.T) {
+	tests := []struct {
+		name     string
+		lesson   registry.Lesson
+		statuses []string
+		check    error // nil: passed; only checked when the lesson is an exercise
+		want     bool
+	}{
+		{"never run", lesson, nil, nil, false},
+		{"ok", lesson, []string{"ok"}, nil, true},
+		{"panicked", lesson, []string{"panicked"}, nil, false},
+		{"timed out", lesson, []string{"timed out"}, nil, false},
+		{"panicked then ok", lesson, []string{"panicked", "ok"}, nil, true},
+		{"ok then timed out", lesson, []string{"ok", "timed out"}, nil, true},
+		{"exercise ok and passed", exerciseLesson, []string{"ok"}, nil, true},
+		{"exercise ok but failed", exerciseLesson, []string{"ok"}, errors.New("wrong"), false},
+		{"exercise panicked but passed", exerciseLesson, []string{"panicked"}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &State{Lessons: make(map[string]*Record)}
+			for _, status := range tt.statuses {
+				s.RecordRun(tt.lesson.Code, status, time.Now())
+			}
+			if tt.lesson.IsExercise() && len(tt.statuses) > 0 {
+				s.RecordCheck(tt.lesson.Code, tt.check, time.Now())
+			}
+			if got := s.Done(tt.lesson); got != tt.want {
+				t.Errorf("Done = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	other := registry.Lesson{Code: "04-19", Title: "Range"}
+	lessons := []registry.Lesson{lesson, other, exerciseLesson}
+	s := &State{Lessons: make(map[string]*Record)}
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.RecordRun(lesson.Code, "ok", at)
+	s.RecordRun(other.Code, "timed out", at.Add(time.Minute))
+	s.RecordRun(exerciseLesson.Code, "panicked", at.Add(2*time.Minute))
+	s.RecordCheck(exerciseLesson.Code, nil, at.Add(2*time.Minute))
+
+	chapters, next := s.Summarize(lessons)
+	want := Chapter{Name: "04", Done: 1, Total: 3, Exercises: 1, ExercisesPassed: 0}
+	if len(chapters) != 1 || chapters[0] != want {
+		t.Errorf("chapters = %+v, want [%+v]", chapters, want)
+	}
+	if next == nil || next.Code != other.Code {
+		t.Errorf("next = %v, want %s", next, other.Code)
+	}
+}
+
+func TestLoadOldFile(t *testing.T) {
+	// A file from before ok_runs counts its last successful run.
+	path := filepath.Join(t.TempDir(), "progress.json")
+	data := `{"lessons": {
+		"04-18": {"runs": 3, "last_run": "2024-01-01T00:00:00Z", "last_status": "ok"},
+		"04-19": {"runs": 2, "last_run": "2024-01-01T00:00:00Z", "last_status": "panicked"}
+	}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Done(lesson) {
+		t.Errorf("04-18, last run ok, is not done")
+	}
+	if s.Done(registry.Lesson{Code: "04-19"}) {
+		t.Errorf("04-19, last run panicked, is done")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", "progress.json")
+	s := &State{Lessons: make(map[string]*Record)}
+	s.RecordRun(lesson.Code, "ok", time.Now())
+	s.RecordRun(lesson.Code, "panicked", time.Now())
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := got.Lessons[lesson.Code]; r == nil || r.Runs != 2 || r.OKRuns != 1 {
+		t.Errorf("loaded %+v, want 2 runs, 1 ok", r)
+	}
+}
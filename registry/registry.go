@@ -0,0 +1,142 @@
+// Package registry holds the lessons of every chapter.
+//
+// Each lesson registers itself from an init function in its own file:
+//
+//	func init() {
+//		registry.Register(registry.Lesson{
+//			Code:    "02-01",
+//			Title:   "Packages",
+//			TourURL: "https://go.dev/tour/basics/1",
+//			Run:     RunBasics01,
+//		})
+//	}
+//
+// The source file is taken from the caller of Register, so it always
+// matches the file the lesson really lives in. Exercises also set Check
+// to a function that validates their solution.
+package registry
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"first-golang/exercise"
+)
+
+// Lesson is one runnable page of the tour.
+type Lesson struct {
+	Code    string // "<chapter>-<page>", e.g. "04-18"
+	Title   string // title of the tour page
+	TourURL string // https://go.dev/tour/... page the lesson follows
+	File    string // source file relative to the module root, set by Register
+	Run     func(w io.Writer)
+
+	// Check validates the solution of an exercise, case by case. It is
+	// nil for other lessons.
+	Check exercise.Checker
+}
+
+// IsExercise reports whether the lesson has a solution to validate.
+func (l Lesson) IsExercise() bool {
+	return l.Check != nil
+}
+
+// Chapter returns the chapter part of the code, e.g. "04" for "04-18".
+func (l Lesson) Chapter() string {
+	chapter, _, _ := strings.Cut(l.Code, "-")
+	return chapter
+}
+
+// Path returns the location of the lesson's source file on disk.
+func (l Lesson) Path() string {
+	return filepath.Join(Root(), filepath.FromSlash(l.File))
+}
+
+// RunFunc returns the name of the lesson's Run function, e.g.
+// "RunMoreTypes18", and the source file it is defined in as recorded in
+// the binary. Both are empty if the runtime does not know the function.
+func (l Lesson) RunFunc() (name, file string) {
+	f := runtime.FuncForPC(reflect.ValueOf(l.Run).Pointer())
+	if f == nil {
+		return "", ""
+	}
+	name = f.Name()
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	file, _ = f.FileLine(f.Entry())
+	return name, file
+}
+
+// Root returns the module root directory, where main.go lives.
+func Root() string {
+	if filepath.IsAbs(root) {
+		return root
+	}
+	// Built with -trimpath: the source tree is unknown, so assume the
+	// working directory is the module root.
+	return "."
+}
+
+var (
+	mu      sync.Mutex
+	lessons = make(map[string]Lesson)
+	root    = moduleRoot()
+)
+
+// moduleRoot returns the directory above this package.
+func moduleRoot() string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		return "."
+	}
+	return filepath.Dir(filepath.Dir(file))
+}
+
+// Register adds a lesson. It panics if the code is already taken or a
+// required field is missing, since both are programming errors.
+func Register(l Lesson) {
+	if l.Code == "" || l.Title == "" || l.Run == nil {
+		panic(fmt.Sprintf("registry: lesson %q needs Code, Title and Run", l.Code))
+	}
+	if _, file, _, ok := runtime.Caller(1); ok {
+		if rel, err := filepath.Rel(root, file); err == nil {
+			l.File = filepath.ToSlash(rel)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if prev, dup := lessons[l.Code]; dup {
+		panic(fmt.Sprintf("registry: lesson %s registered twice (%s and %s)", l.Code, prev.File, l.File))
+	}
+	lessons[l.Code] = l
+}
+
+// All returns every registered lesson ordered by code.
+func All() []Lesson {
+	mu.Lock()
+	defer mu.Unlock()
+	all := make([]Lesson, 0, len(lessons))
+	for _, l := range lessons {
+		all = append(all, l)
+	}
+	slices.SortFunc(all, func(a, b Lesson) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return all
+}
+
+// Lookup returns the lesson registered under code.
+func Lookup(code string) (Lesson, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	l, ok := lessons[code]
+	return l, ok
+}
@@ -0,0 +1,251 @@
+// Package report writes lessons, run results and benchmark results in
+// machine-readable formats (JSON, YAML and TSV) for scripts and editors.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"first-golang/registry"
+	"first-golang/runner"
+)
+
+// Formats lists the accepted format names; "text" is the human-readable
+// output of each command and is not handled here.
+var Formats = []string{"text", "json", "yaml", "tsv"}
+
+// CheckFormat returns an error unless name is one of Formats.
+func CheckFormat(name string) error {
+	for _, f := range Formats {
+		if name == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown format %q (want %s)", name, strings.Join(Formats, ", "))
+}
+
+// Lesson describes a registered lesson.
+type Lesson struct {
+	Code     string `json:"code"`
+	Title    string `json:"title"`
+	File     string `json:"file"` // absolute path of the source file
+	TourURL  string `json:"tour_url"`
+	Exercise bool   `json:"exercise"`
+}
+
+// NewLesson returns the description of l.
+func NewLesson(l registry.Lesson) Lesson {
+	return Lesson{
+		Code:     l.Code,
+		Title:    l.Title,
+		File:     l.Path(),
+		TourURL:  l.TourURL,
+		Exercise: l.IsExercise(),
+	}
+}
+
+func (l Lesson) fields() []field {
+	return []field{
+		{"code", l.Code, false},
+		{"title", l.Title, false},
+		{"file", l.File, false},
+		{"tour_url", l.TourURL, false},
+		{"exercise", strconv.FormatBool(l.Exercise), true},
+	}
+}
+
+// Run describes one supervised run of a lesson.
+type Run struct {
+	Lesson
+	Status     string  `json:"status"`
+	DurationMS float64 `json:"duration_ms"`
+	Output     string  `json:"output"`
+	Panic      string  `json:"panic,omitempty"`
+	Check      string  `json:"check,omitempty"` // "passed" or the failure, for exercises that finished
+}
+
+// NewRun returns the description of a run from its result, its captured
+// output and, for an exercise that was checked, the check verdict.
+func NewRun(r runner.Result, output []byte, checked bool, checkErr error) Run {
+	run := Run{
+		Lesson:     NewLesson(r.Lesson),
+		Status:     r.Status.String(),
+		DurationMS: float64(r.Elapsed.Round(time.Microsecond)) / float64(time.Millisecond),
+		Output:     string(output),
+	}
+	if r.Status == runner.StatusPanicked {
+		run.Panic = fmt.Sprint(r.Panic)
+	}
+	if checked {
+		run.Check = "passed"
+		if checkErr != nil {
+			run.Check = "failed: " + checkErr.Error()
+		}
+	}
+	return run
+}
+
+func (r Run) fields() []field {
+	return append(r.Lesson.fields(),
+		field{"status", r.Status, false},
+		field{"duration_ms", strconv.FormatFloat(r.DurationMS, 'f', 3, 64), true},
+		field{"output", r.Output, false},
+		field{"panic", r.Panic, false},
+		field{"check", r.Check, false},
+	)
+}
+
+// Bench describes the result of one benchmark of a lesson.
+type Bench struct {
+	Code        string  `json:"code"`
+	Title       string  `json:"title"`
+	Benchmark   string  `json:"benchmark"`
+	N           int     `json:"n"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// NewBench returns the description of a benchmark result.
+func NewBench(r runner.BenchResult) Bench {
+	b := Bench{
+		Code:        r.Lesson.Code,
+		Title:       r.Lesson.Title,
+		Benchmark:   r.Name,
+		N:           r.N,
+		NsPerOp:     r.NsPerOp,
+		BytesPerOp:  r.BytesPerOp,
+		AllocsPerOp: r.AllocsPerOp,
+	}
+	if r.Err != nil {
+		b.Error = r.Err.Error()
+	}
+	return b
+}
+
+func (b Bench) fields() []field {
+	return []field{
+		{"code", b.Code, false},
+		{"title", b.Title, false},
+		{"benchmark", b.Benchmark, false},
+		{"n", strconv.Itoa(b.N), true},
+		{"ns_per_op", strconv.FormatFloat(b.NsPerOp, 'f', 2, 64), true},
+		{"bytes_per_op", strconv.FormatInt(b.BytesPerOp, 10), true},
+		{"allocs_per_op", strconv.FormatInt(b.AllocsPerOp, 10), true},
+		{"error", b.Error, false},
+	}
+}
+
+type field struct {
+	name, value string
+	bare        bool // a boolean or a number, written unquoted in YAML
+}
+
+// Record is a Lesson, a Run or a Bench.
+type Record interface {
+	fields() []field
+}
+
+// Write writes the records to w in the named format.
+func Write[R Record](w io.Writer, format string, records []R) error {
+	switch format {
+	case "json":
+		if records == nil {
+			records = []R{} // [] rather than null
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(records)
+	case "yaml":
+		return writeYAML(w, records)
+	case "tsv":
+		return writeTSV(w, records)
+	}
+	return CheckFormat(format)
+}
+
+// writeYAML writes a sequence of flat mappings. Every value is a quoted
+// string or a literal block, so no value is mistaken for another type;
+// fields marked bare, booleans and numbers, are written as they are.
+func writeYAML[R Record](w io.Writer, records []R) error {
+	if len(records) == 0 {
+		_, err := fmt.Fprintln(w, "[]")
+		return err
+	}
+	var b strings.Builder
+	for _, r := range records {
+		for i, f := range r.fields() {
+			if i == 0 {
+				b.WriteString("- ")
+			} else {
+				b.WriteString("  ")
+			}
+			b.WriteString(f.name)
+			b.WriteString(":")
+			switch {
+			case f.bare:
+				b.WriteString(" " + f.value + "\n")
+			case strings.Contains(strings.TrimSuffix(f.value, "\n"), "\n"):
+				writeBlock(&b, f.value)
+			default:
+				b.WriteString(" " + strconv.Quote(f.value) + "\n")
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeBlock writes a multi-line value as a YAML literal block, keeping
+// its final line break or the lack of one.
+func writeBlock(b *strings.Builder, s string) {
+	chomp := "-"
+	if strings.HasSuffix(s, "\n") {
+		chomp = ""
+		s = strings.TrimSuffix(s, "\n")
+	}
+	b.WriteString(" |" + chomp)
+	if strings.HasPrefix(s, " ") || strings.HasPrefix(s, "\n") {
+		b.WriteString("4") // the indentation cannot be told from the first line
+	}
+	b.WriteString("\n")
+	for _, line := range strings.Split(s, "\n") {
+		if line == "" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString("    " + line + "\n")
+	}
+}
+
+// writeTSV writes a header line and a line per record. Backslashes, tabs
+// and line breaks in values are escaped as \\, \t, \n and \r.
+func writeTSV[R Record](w io.Writer, records []R) error {
+	var b strings.Builder
+	var zero R
+	for i, f := range zero.fields() {
+		if i > 0 {
+			b.WriteByte('\t')
+		}
+		b.WriteString(f.name)
+	}
+	b.WriteByte('\n')
+	escape := strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+	for _, r := range records {
+		for i, f := range r.fields() {
+			if i > 0 {
+				b.WriteByte('\t')
+			}
+			b.WriteString(escape.Replace(f.value))
+		}
+		b.WriteByte('\n')
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
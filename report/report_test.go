@@ -0,0 +1,92 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func write[R Record](t *testing.T, format string, records []R) string {
+	t.Helper()
+	var b strings.Builder
+	if err := Write(&b, format, records); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestYAMLQuoting(t *testing.T) {
+	got := write(t, "yaml", []Bench{{
+		Code:      "04-18",
+		Title:     `Exercise: "Slices"`,
+		Benchmark: "Pic",
+		N:         100,
+		NsPerOp:   1234.5,
+	}})
+	want := `- code: "04-18"
+  title: "Exercise: \"Slices\""
+  benchmark: "Pic"
+  n: 100
+  ns_per_op: 1234.50
+  bytes_per_op: 0
+  allocs_per_op: 0
+  error: ""
+`
+	if got != want {
+		t.Errorf("yaml =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestYAMLBlock(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{"one line\n", ` "one line\n"` + "\n"},
+		{"a\nb\n", " |\n    a\n    b\n"},
+		{"a\nb", " |-\n    a\n    b\n"},
+		{"a\n\nb\n", " |\n    a\n\n    b\n"},
+		{"  indented\nb\n", " |4\n      indented\n    b\n"},
+	}
+	for _, tt := range tests {
+		got := write(t, "yaml", []Run{{Output: tt.value}})
+		_, got, _ = strings.Cut(got, "  output:")
+		got, _, _ = strings.Cut(got, "  panic:")
+		if got != tt.want {
+			t.Errorf("output %q written as %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestTSVEscaping(t *testing.T) {
+	got := write(t, "tsv", []Bench{{Code: "07-10", Title: "tab\there", Benchmark: `back\slash`, Error: "line 1\nline 2\r"}})
+	want := "code\ttitle\tbenchmark\tn\tns_per_op\tbytes_per_op\tallocs_per_op\terror\n" +
+		`07-10	tab\there	back\\slash	0	0.00	0	0	line 1\nline 2\r` + "\n"
+	if got != want {
+		t.Errorf("tsv =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	tests := []struct {
+		format, want string
+	}{
+		{"json", "[]\n"},
+		{"yaml", "[]\n"},
+		{"tsv", "code\ttitle\tfile\ttour_url\texercise\n"},
+	}
+	for _, tt := range tests {
+		if got := write[Lesson](t, tt.format, nil); got != tt.want {
+			t.Errorf("%s of no lessons = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestCheckFormat(t *testing.T) {
+	for _, f := range Formats {
+		if err := CheckFormat(f); err != nil {
+			t.Errorf("CheckFormat(%q) = %v", f, err)
+		}
+	}
+	if err := Write(new(strings.Builder), "xml", []Lesson{}); err == nil {
+		t.Errorf("Write in xml succeeded")
+	}
+}
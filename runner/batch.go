@@ -0,0 +1,70 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"first-golang/registry"
+)
+
+// Result describes one finished lesson.
+type Result struct {
+	Lesson  registry.Lesson
+	Status  Status
+	Elapsed time.Duration
+	Panic   any    // the recovered value when Status is StatusPanicked
+	Stack   []byte // the goroutine stack at the panic
+}
+
+// Failed reports whether the lesson did not finish normally.
+func (r Result) Failed() bool {
+	return r.Status != StatusOK
+}
+
+// Batch runs the lessons one after another under the supervisor. Each
+// lesson's output is framed by a header and a footer with its status and
+// elapsed time written to w, and a summary of the whole batch follows the
+// last lesson. A lesson that panics or times out does not stop the batch.
+func Batch(w io.Writer, lessons []registry.Lesson, opts Options) []Result {
+	results := make([]Result, 0, len(lessons))
+	start := time.Now()
+	for _, l := range lessons {
+		fmt.Fprintf(w, "=== %s %s ===\n", l.Code, l.Title)
+		r := Run(l, w, opts)
+		Report(w, r)
+		fmt.Fprintln(w)
+		results = append(results, r)
+	}
+	Summary(w, results, time.Since(start))
+	return results
+}
+
+// Report writes the footer of a lesson: its status, elapsed time and,
+// for a panic, the recovered value and stack.
+func Report(w io.Writer, r Result) {
+	switch r.Status {
+	case StatusPanicked:
+		fmt.Fprintf(w, "--- %s panicked after %v: %v\n", r.Lesson.Code, r.Elapsed.Round(time.Microsecond), r.Panic)
+		w.Write(r.Stack)
+	case StatusTimedOut:
+		fmt.Fprintf(w, "--- %s timed out after %v\n", r.Lesson.Code, r.Elapsed.Round(time.Millisecond))
+	default:
+		fmt.Fprintf(w, "--- %s %s in %v\n", r.Lesson.Code, r.Status, r.Elapsed.Round(time.Microsecond))
+	}
+}
+
+// Summary writes a table of the results, the total wall time and how many
+// lessons ended in each status.
+func Summary(w io.Writer, results []Result, total time.Duration) {
+	counts := make(map[Status]int)
+	for _, r := range results {
+		counts[r.Status]++
+	}
+	fmt.Fprintf(w, "=== Summary: %d lessons in %v (ok %d, panicked %d, timed out %d) ===\n",
+		len(results), total.Round(time.Microsecond),
+		counts[StatusOK], counts[StatusPanicked], counts[StatusTimedOut])
+	for _, r := range results {
+		fmt.Fprintf(w, "  %s  %-40s %-9s %12v\n", r.Lesson.Code, r.Lesson.Title, r.Status, r.Elapsed.Round(time.Microsecond))
+	}
+}
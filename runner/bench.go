@@ -0,0 +1,204 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"first-golang/registry"
+)
+
+// Benchmarks of lessons are ordinary go test benchmarks in the _test.go
+// files of the lesson's chapter. The benchmark of a lesson is named after
+// its Run function, BenchmarkMoreTypes18 for RunMoreTypes18, and times
+// each piece of code in a sub-benchmark named after it:
+//
+//	func BenchmarkMoreTypes18(b *testing.B) {
+//		b.Run("Pic", func(b *testing.B) { ... })
+//	}
+//
+// so 'go test -bench .' runs them too.
+
+// BenchOptions controls Bench.
+type BenchOptions struct {
+	// BenchTime is go test's -benchtime: a duration such as "1s", or a
+	// number of iterations such as "100x". Empty means go test's default.
+	BenchTime string
+	// Timeout bounds each go test run. Zero means no limit.
+	Timeout time.Duration
+}
+
+// BenchResult is the outcome of one benchmark of a lesson.
+type BenchResult struct {
+	Lesson      registry.Lesson
+	Name        string // the sub-benchmark, e.g. "Pic"
+	N           int
+	NsPerOp     float64
+	BytesPerOp  int64
+	AllocsPerOp int64
+	Err         error // the benchmark failed
+}
+
+// BenchName returns the name of a lesson's benchmark function, or "" if
+// the name of its Run function is unknown.
+func BenchName(l registry.Lesson) string {
+	name, _ := l.RunFunc()
+	if rest, ok := strings.CutPrefix(name, "Run"); ok {
+		return "Benchmark" + rest
+	}
+	return ""
+}
+
+// HasBenchmark reports whether the chapter of a lesson has a benchmark
+// for it in its _test.go files.
+func HasBenchmark(l registry.Lesson) bool {
+	name := BenchName(l)
+	if name == "" {
+		return false
+	}
+	files, _ := filepath.Glob(filepath.Join(filepath.Dir(l.Path()), "*_test.go"))
+	for _, file := range files {
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		for _, d := range f.Decls {
+			if fd, ok := d.(*ast.FuncDecl); ok && fd.Recv == nil && fd.Name.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Bench runs the benchmarks of the lessons with 'go test -bench -benchmem'
+// in their chapters, one go test run per chapter, and returns the results
+// in the order of lessons and then of the sub-benchmarks. goCmd is the go
+// command. Lessons without a benchmark are skipped. The error reports a
+// go test run that failed as a whole, for instance because it did not
+// build, panicked or timed out; the results of the other runs are still
+// returned.
+func Bench(ctx context.Context, goCmd string, lessons []registry.Lesson, opts BenchOptions) ([]BenchResult, error) {
+	var (
+		dirs    []string
+		byDir   = make(map[string][]registry.Lesson)
+		results []BenchResult
+		errs    []error
+	)
+	for _, l := range lessons {
+		if !HasBenchmark(l) {
+			continue
+		}
+		dir := filepath.Dir(l.File)
+		if _, ok := byDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], l)
+	}
+	for _, dir := range dirs {
+		rs, err := benchDir(ctx, goCmd, dir, byDir[dir], opts)
+		results = append(results, rs...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// benchLine matches a result line of go test -benchmem, such as
+//
+//	BenchmarkMoreTypes18/Pic-8   9266   126125 ns/op   65536 B/op   256 allocs/op
+var benchLine = regexp.MustCompile(`^(Benchmark\w+)/(\S+?)(?:-\d+)?\s+(\d+)\s+([\d.]+) ns/op(?:\s+(\d+) B/op)?(?:\s+(\d+) allocs/op)?`)
+
+// benchFail matches the line go test prints for a failed benchmark.
+var benchFail = regexp.MustCompile(`^\s*--- FAIL: (Benchmark\w+)/(\S+?)(?:-\d+)?$`)
+
+// benchDir runs the benchmarks of lessons of one chapter.
+func benchDir(ctx context.Context, goCmd, dir string, lessons []registry.Lesson, opts BenchOptions) ([]BenchResult, error) {
+	byName := make(map[string]registry.Lesson)
+	var names []string
+	for _, l := range lessons {
+		name := BenchName(l)
+		byName[name] = l
+		names = append(names, name)
+	}
+	args := []string{"test", "-run", "^$",
+		"-bench", "^(" + strings.Join(names, "|") + ")$", "-benchmem",
+		"-timeout", opts.Timeout.String()}
+	if opts.BenchTime != "" {
+		args = append(args, "-benchtime", opts.BenchTime)
+	}
+	cmd := exec.CommandContext(ctx, goCmd, append(args, "./"+dir)...)
+	cmd.Dir = registry.Root()
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	runErr := cmd.Run()
+
+	var results []BenchResult
+	sc := bufio.NewScanner(bytes.NewReader(out.Bytes()))
+	for sc.Scan() {
+		line := sc.Text()
+		if m := benchFail.FindStringSubmatch(line); m != nil {
+			if l, ok := byName[m[1]]; ok {
+				results = append(results, BenchResult{Lesson: l, Name: m[2], Err: errors.New("benchmark failed")})
+			}
+			continue
+		}
+		m := benchLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		l, ok := byName[m[1]]
+		if !ok {
+			continue
+		}
+		r := BenchResult{Lesson: l, Name: m[2]}
+		r.N, _ = strconv.Atoi(m[3])
+		r.NsPerOp, _ = strconv.ParseFloat(m[4], 64)
+		r.BytesPerOp, _ = strconv.ParseInt(m[5], 10, 64)
+		r.AllocsPerOp, _ = strconv.ParseInt(m[6], 10, 64)
+		results = append(results, r)
+	}
+	sortByLesson(results, names)
+
+	if runErr != nil {
+		var exit *exec.ExitError
+		if !errors.As(runErr, &exit) {
+			return results, runErr
+		}
+		if failed(results) {
+			return results, nil // reported per benchmark
+		}
+		return results, fmt.Errorf("go test ./%s: %v\n%s", dir, runErr, strings.TrimSpace(out.String()))
+	}
+	return results, nil
+}
+
+// sortByLesson orders results by the position of their lesson's benchmark
+// in names, keeping the order of the sub-benchmarks.
+func sortByLesson(results []BenchResult, names []string) {
+	slices.SortStableFunc(results, func(a, b BenchResult) int {
+		return slices.Index(names, BenchName(a.Lesson)) - slices.Index(names, BenchName(b.Lesson))
+	})
+}
+
+func failed(results []BenchResult) bool {
+	for _, r := range results {
+		if r.Err != nil {
+			return true
+		}
+	}
+	return false
+}
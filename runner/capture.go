@@ -0,0 +1,14 @@
+package runner
+
+import (
+	"bytes"
+
+	"first-golang/registry"
+)
+
+// Capture runs the lesson like Run and returns everything it wrote.
+func Capture(l registry.Lesson, opts Options) (Result, []byte) {
+	var buf bytes.Buffer
+	r := Run(l, &buf, opts)
+	return r, buf.Bytes()
+}
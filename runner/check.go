@@ -0,0 +1,52 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"first-golang/exercise"
+	"first-golang/registry"
+)
+
+// Check runs the checker of an exercise under the same supervision as
+// Run: a panic in the solution or a checker still running after
+// opts.Timeout ends in a failing verdict rather than taking the program
+// down. A lesson that is not an exercise gets a failing verdict too.
+func Check(l registry.Lesson, opts Options) exercise.Verdict {
+	if !l.IsExercise() {
+		return exercise.Failed(l.Code, "not an exercise")
+	}
+	done := make(chan exercise.Verdict, 1)
+	go func() {
+		done <- exercise.Run(l.Check)
+	}()
+
+	var timeout <-chan time.Time
+	if opts.Timeout > 0 {
+		t := time.NewTimer(opts.Timeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+	select {
+	case v := <-done:
+		return v
+	case <-timeout:
+		return exercise.Failed("timeout", "the check was still running after %v", opts.Timeout)
+	}
+}
+
+// ReportCheck writes the verdict of an exercise: one line with the
+// number of cases that passed and, when it failed, a line per failing
+// case.
+func ReportCheck(w io.Writer, l registry.Lesson, v exercise.Verdict) {
+	passed, total := v.Score()
+	if v.Passed() {
+		fmt.Fprintf(w, "--- %s check passed (%d/%d cases)\n", l.Code, passed, total)
+		return
+	}
+	fmt.Fprintf(w, "--- %s check failed (%d/%d cases)\n", l.Code, passed, total)
+	for _, c := range v.Failures() {
+		fmt.Fprintf(w, "    %s\n", c)
+	}
+}
@@ -0,0 +1,90 @@
+// Package runner selects lessons from the registry and executes them.
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"first-golang/registry"
+)
+
+var (
+	// ErrNoMatch is returned when a pattern selects no lesson.
+	ErrNoMatch = errors.New("no lesson matches")
+	// ErrBadPattern is returned for a malformed pattern.
+	ErrBadPattern = errors.New("bad pattern")
+)
+
+// Select returns the lessons matched by the patterns. Each pattern is one of
+//
+//	04-18        a single lesson
+//	05           a whole chapter
+//	04-10:04-18  an inclusive range of codes
+//	07-0*        a glob, as understood by path.Match
+//
+// Lessons are returned in pattern order, each pattern's matches in code
+// order, and a lesson matched by several patterns is returned only once.
+func Select(lessons []registry.Lesson, patterns ...string) ([]registry.Lesson, error) {
+	var selected []registry.Lesson
+	seen := make(map[string]bool)
+	for _, p := range patterns {
+		match, err := matcher(p)
+		if err != nil {
+			return nil, err
+		}
+		n := 0
+		for _, l := range lessons {
+			if !match(l.Code) {
+				continue
+			}
+			n++
+			if !seen[l.Code] {
+				seen[l.Code] = true
+				selected = append(selected, l)
+			}
+		}
+		if n == 0 {
+			return nil, fmt.Errorf("%w %q", ErrNoMatch, p)
+		}
+	}
+	return selected, nil
+}
+
+// matcher turns a pattern into a predicate on lesson codes.
+func matcher(p string) (func(code string) bool, error) {
+	switch {
+	case p == "":
+		return nil, fmt.Errorf("%w: empty pattern", ErrBadPattern)
+
+	case strings.Contains(p, ":"):
+		from, to, _ := strings.Cut(p, ":")
+		if from == "" || to == "" || from > to {
+			return nil, fmt.Errorf("%w %q: want <from>:<to> with from <= to", ErrBadPattern, p)
+		}
+		return func(code string) bool {
+			return from <= code && code <= to
+		}, nil
+
+	case strings.ContainsAny(p, "*?["):
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrBadPattern, p, err)
+		}
+		return func(code string) bool {
+			ok, _ := path.Match(p, code)
+			return ok
+		}, nil
+
+	case !strings.Contains(p, "-"):
+		// A bare chapter number.
+		return func(code string) bool {
+			return strings.HasPrefix(code, p+"-")
+		}, nil
+
+	default:
+		return func(code string) bool {
+			return code == p
+		}, nil
+	}
+}
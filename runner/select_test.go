@@ -0,0 +1,50 @@
+package runner
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"first-golang/registry"
+)
+
+func TestSelect(t *testing.T) {
+	var lessons []registry.Lesson
+	for _, code := range []string{"04-09", "04-10", "04-18", "04-27", "05-01", "07-01", "07-09", "07-10"} {
+		lessons = append(lessons, registry.Lesson{Code: code})
+	}
+	tests := []struct {
+		patterns []string
+		want     []string
+		err      error
+	}{
+		{[]string{"04-18"}, []string{"04-18"}, nil},
+		{[]string{"05"}, []string{"05-01"}, nil},
+		{[]string{"04-10:04-18"}, []string{"04-10", "04-18"}, nil},
+		{[]string{"04-10:05"}, []string{"04-10", "04-18", "04-27"}, nil},
+		{[]string{"07-0*"}, []string{"07-01", "07-09"}, nil},
+		{[]string{"0?-1*"}, []string{"04-10", "04-18", "07-10"}, nil},
+		{[]string{"07", "04-18"}, []string{"07-01", "07-09", "07-10", "04-18"}, nil},
+		{[]string{"04-18", "04"}, []string{"04-18", "04-09", "04-10", "04-27"}, nil},
+		{[]string{"99-99"}, nil, ErrNoMatch},
+		{[]string{"04-18", "06"}, nil, ErrNoMatch},
+		{[]string{""}, nil, ErrBadPattern},
+		{[]string{"04-18:04-10"}, nil, ErrBadPattern},
+		{[]string{":04-10"}, nil, ErrBadPattern},
+		{[]string{"04-[1"}, nil, ErrBadPattern},
+	}
+	for _, tt := range tests {
+		got, err := Select(lessons, tt.patterns...)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Select(%q) error = %v, want %v", tt.patterns, err, tt.err)
+			continue
+		}
+		var codes []string
+		for _, l := range got {
+			codes = append(codes, l.Code)
+		}
+		if !slices.Equal(codes, tt.want) {
+			t.Errorf("Select(%q) = %q, want %q", tt.patterns, codes, tt.want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package runner
+
+import (
+	"io"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"first-golang/registry"
+)
+
+// Status is the outcome of a supervised lesson.
+type Status int
+
+const (
+	StatusOK Status = iota
+	StatusPanicked
+	StatusTimedOut
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusPanicked:
+		return "panicked"
+	case StatusTimedOut:
+		return "timed out"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultTimeout bounds a lesson when the caller does not choose a limit.
+// It is long enough for every lesson except the ones that never return,
+// such as 03-04 "Forever".
+const DefaultTimeout = 10 * time.Second
+
+// Options controls how lessons are supervised.
+type Options struct {
+	// Timeout is how long a lesson may run. Zero means no limit.
+	Timeout time.Duration
+}
+
+// Run executes a single lesson under a supervisor, sending its output to
+// w: a panic is recovered and reported in the result together with its
+// stack, and a lesson that is still running after opts.Timeout is
+// reported as timed out.
+//
+// Go cannot kill a goroutine, so a timed-out lesson is abandoned rather
+// than stopped. Its writer is closed off at that point: the next write
+// parks the goroutine for good, so a lesson like 03-04 "Forever" neither
+// keeps printing into later output nor keeps spinning a CPU.
+func Run(l registry.Lesson, w io.Writer, opts Options) Result {
+	type outcome struct {
+		panic any
+		stack []byte
+	}
+	done := make(chan outcome, 1)
+	out := &gate{w: w}
+
+	start := time.Now()
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- outcome{panic: p, stack: debug.Stack()}
+				return
+			}
+			done <- outcome{}
+		}()
+		l.Run(out)
+	}()
+
+	var timeout <-chan time.Time
+	if opts.Timeout > 0 {
+		t := time.NewTimer(opts.Timeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+
+	r := Result{Lesson: l}
+	select {
+	case o := <-done:
+		r.Elapsed = time.Since(start)
+		if o.stack != nil {
+			r.Status = StatusPanicked
+			r.Panic = o.panic
+			r.Stack = o.stack
+		}
+	case <-timeout:
+		r.Elapsed = time.Since(start)
+		r.Status = StatusTimedOut
+	}
+	// Goroutines a lesson leaves behind must not write into whatever w
+	// is used for next.
+	out.close()
+	return r
+}
+
+// gate forwards writes to w until it is closed; after that every write
+// blocks forever. Writes are serialized, so lessons that print from
+// several goroutines can share a w that is not safe for concurrent use,
+// such as a bytes.Buffer.
+type gate struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closed bool
+}
+
+func (g *gate) Write(p []byte) (int, error) {
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		select {}
+	}
+	defer g.mu.Unlock()
+	return g.w.Write(p)
+}
+
+func (g *gate) close() {
+	g.mu.Lock()
+	g.closed = true
+	g.mu.Unlock()
+}
@@ -0,0 +1,165 @@
+// Package scaffold creates new lesson files from a chapter's
+// 00-template.go.
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"first-golang/registry"
+)
+
+// Template is the file in each chapter directory new lessons start from,
+// and TemplateFunc the function in it that becomes the lesson's Run.
+const (
+	Template     = "00-template.go"
+	TemplateFunc = "EmptyTemplate"
+)
+
+// Lesson describes the lesson to create.
+type Lesson struct {
+	Chapter string // chapter number ("04") or directory ("04-more-types")
+	Page    int    // page of the tour, e.g. 27
+	Title   string
+	TourURL string
+}
+
+// Result describes a created lesson.
+type Result struct {
+	Code    string
+	File    string // relative to the module root
+	RunFunc string
+}
+
+var trailingDigits = regexp.MustCompile(`\d+$`)
+
+// Create writes the new lesson file under root and returns what it made.
+// The file is named "<page>-<title with dashes>.go" after the existing
+// lessons, and its Run function after the Run functions of the chapter's
+// other lessons ("RunMoreTypes27"). It refuses to overwrite a file or to
+// reuse a lesson code that is already registered.
+func Create(root string, lessons []registry.Lesson, nl Lesson) (Result, error) {
+	dir, err := chapterDir(root, nl.Chapter)
+	if err != nil {
+		return Result{}, err
+	}
+	chapter, _, _ := strings.Cut(dir, "-")
+	page := fmt.Sprintf("%02d", nl.Page)
+	res := Result{
+		Code: chapter + "-" + page,
+		File: path.Join(dir, page+"-"+fileTitle(nl.Title)+".go"),
+	}
+	if _, ok := registry.Lookup(res.Code); ok {
+		return Result{}, fmt.Errorf("lesson %s is already registered", res.Code)
+	}
+	dst := filepath.Join(root, filepath.FromSlash(res.File))
+	if _, err := os.Stat(dst); err == nil {
+		return Result{}, fmt.Errorf("%s already exists", res.File)
+	}
+
+	prefix, err := runPrefix(dir, chapter, lessons)
+	if err != nil {
+		return Result{}, err
+	}
+	res.RunFunc = prefix + page
+
+	tmpl, err := os.ReadFile(filepath.Join(root, dir, Template))
+	if errors.Is(err, os.ErrNotExist) {
+		return Result{}, fmt.Errorf("chapter %s has no %s to start from", dir, Template)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	src, err := fill(tmpl, res, nl)
+	if err != nil {
+		return Result{}, fmt.Errorf("%s/%s: %v", dir, Template, err)
+	}
+	if err := os.WriteFile(dst, src, 0o644); err != nil {
+		return Result{}, err
+	}
+	return res, nil
+}
+
+// chapterDir finds the directory of a chapter given by number or name.
+func chapterDir(root, chapter string) (string, error) {
+	if chapter == "" {
+		return "", errors.New("missing chapter")
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if e.IsDir() && (e.Name() == chapter || strings.HasPrefix(e.Name(), chapter+"-")) {
+			return e.Name(), nil
+		}
+	}
+	return "", fmt.Errorf("no chapter directory for %q", chapter)
+}
+
+// fileTitle turns a title into the file name part used by the existing
+// lessons: "Exercise: Web Crawler" becomes "Exercise:Web-Crawler".
+// Everything but spaces is kept.
+func fileTitle(title string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(title, ": ", ":")), "-")
+}
+
+// runPrefix returns the Run function name of the chapter without its
+// page number, taken from a lesson already registered in the chapter.
+func runPrefix(dir, chapter string, lessons []registry.Lesson) (string, error) {
+	for _, l := range lessons {
+		if l.Chapter() != chapter || path.Dir(l.File) != dir {
+			continue
+		}
+		name, _ := l.RunFunc()
+		if p := trailingDigits.ReplaceAllString(name, ""); strings.HasPrefix(p, "Run") && p != "Run" {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("chapter %s has no lessons to name the Run function after", dir)
+}
+
+// fill turns the template into the lesson: the header becomes the tour
+// URL, the template function is renamed, and the registry import and an
+// init function registering the lesson are added.
+func fill(tmpl []byte, res Result, nl Lesson) ([]byte, error) {
+	src := string(tmpl)
+	if first, rest, ok := strings.Cut(src, "\n"); ok && strings.HasPrefix(first, "// https://") {
+		src = rest
+	}
+	src = "// " + nl.TourURL + "\n" + src
+
+	decl := "func " + TemplateFunc + "("
+	if !strings.Contains(src, decl) {
+		return nil, fmt.Errorf("no %s function", TemplateFunc)
+	}
+	src = strings.Replace(src, decl, registration(res, nl)+"func "+res.RunFunc+"(", 1)
+
+	open := strings.Index(src, "import (")
+	if open < 0 {
+		return nil, errors.New("no import block")
+	}
+	end := open + strings.Index(src[open:], "\n)")
+	src = src[:end] + "\n\n\t\"first-golang/registry\"" + src[end:]
+
+	return format.Source([]byte(src))
+}
+
+func registration(res Result, nl Lesson) string {
+	return fmt.Sprintf(`func init() {
+	registry.Register(registry.Lesson{
+		Code:    %q,
+		Title:   %q,
+		TourURL: %q,
+		Run:     %s,
+	})
+}
+
+`, res.Code, nl.Title, nl.TourURL, res.RunFunc)
+}
@@ -0,0 +1,113 @@
+package scaffold
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"first-golang/registry"
+)
+
+func TestFileTitle(t *testing.T) {
+	tests := []struct {
+		title, want string
+	}{
+		{"Exercise: Web Crawler", "Exercise:Web-Crawler"},
+		{"Methods continued", "Methods-continued"},
+		{"  Range   and Close ", "Range-and-Close"},
+		{"Hello, 世界", "Hello,-世界"},
+		{"sync.Mutex", "sync.Mutex"},
+	}
+	for _, tt := range tests {
+		if got := fileTitle(tt.title); got != tt.want {
+			t.Errorf("fileTitle(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+// RunMoreTypes18 stands in for a registered lesson of the chapter, whose
+// name Create copies.
+func RunMoreTypes18(w io.Writer) {}
+
+const template = `// https://go.dev/tour/moretypes/1
+package moreTypes
+
+import (
+	"fmt"
+	"io"
+)
+
+func EmptyTemplate(w io.Writer) {
+	fmt.Fprintln(w, "Empty template")
+}
+`
+
+func TestCreate(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "04-more-types")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, Template), []byte(template), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lessons := []registry.Lesson{{Code: "04-18", File: "04-more-types/18-Exercise:Slices.go", Run: RunMoreTypes18}}
+	nl := Lesson{Chapter: "04", Page: 27, Title: "Exercise: Stringers again", TourURL: "https://go.dev/tour/moretypes/27"}
+
+	res, err := Create(root, lessons, nl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Result{Code: "04-27", File: "04-more-types/27-Exercise:Stringers-again.go", RunFunc: "RunMoreTypes27"}
+	if res != want {
+		t.Errorf("Create = %+v, want %+v", res, want)
+	}
+	src, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(res.File)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"// https://go.dev/tour/moretypes/27\npackage moreTypes\n",
+		"\t\"first-golang/registry\"\n",
+		"Code:    \"04-27\",",
+		"Title:   \"Exercise: Stringers again\",",
+		"Run:     RunMoreTypes27,",
+		"func RunMoreTypes27(w io.Writer) {",
+	} {
+		if !strings.Contains(string(src), s) {
+			t.Errorf("created file lacks %q:\n%s", s, src)
+		}
+	}
+
+	// The chapter may be named by its directory; the file now exists.
+	nl.Chapter = "04-more-types"
+	if _, err := Create(root, lessons, nl); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("second Create error = %v, want already exists", err)
+	}
+}
+
+func TestCreateErrors(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "05-methods"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		chapter string
+		lessons []registry.Lesson
+		want    string
+	}{
+		{"no chapter", "", nil, "missing chapter"},
+		{"unknown chapter", "09", nil, "no chapter directory"},
+		{"no lessons to copy", "05", nil, "no lessons to name the Run function after"},
+		{"no template", "05", []registry.Lesson{{Code: "05-01", File: "05-methods/01-Methods.go", Run: RunMoreTypes18}}, "has no 00-template.go"},
+	}
+	for _, tt := range tests {
+		_, err := Create(root, tt.lessons, Lesson{Chapter: tt.chapter, Page: 30, Title: "New", TourURL: "https://go.dev/tour/methods/30"})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
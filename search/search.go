@@ -0,0 +1,281 @@
+// Package search indexes the lessons for full-text search: their titles
+// and codes, and the identifiers and comments in their source files.
+package search
+
+import (
+	"cmp"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"slices"
+	"strings"
+
+	"first-golang/registry"
+)
+
+// Field is the part of a lesson an entry was taken from.
+type Field int
+
+const (
+	Title Field = iota
+	Code
+	Identifier
+	Comment
+)
+
+// weight is how much one matching entry of a field adds to a score. A
+// title hit says far more about a lesson than one comment line does.
+func (f Field) weight() int {
+	switch f {
+	case Title, Code:
+		return 10
+	case Identifier:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// entry is one searchable piece of text.
+type entry struct {
+	field Field
+	line  int    // line in the source file, 0 for title and code
+	key   string // lower-cased text that terms are matched against
+	text  string // what a snippet shows
+}
+
+type doc struct {
+	lesson  registry.Lesson
+	entries []entry
+}
+
+// Index is a search index over a set of lessons.
+type Index struct {
+	docs []doc
+}
+
+// Build indexes the lessons. A lesson whose source cannot be read or
+// parsed is still found by its title and code.
+func Build(lessons []registry.Lesson) *Index {
+	ix := &Index{docs: make([]doc, 0, len(lessons))}
+	for _, l := range lessons {
+		d := doc{lesson: l, entries: []entry{
+			{field: Title, key: strings.ToLower(l.Title), text: l.Title},
+			{field: Code, key: strings.ToLower(l.Code), text: l.Code},
+		}}
+		if src, err := os.ReadFile(l.Path()); err == nil {
+			d.entries = append(d.entries, sourceEntries(src)...)
+		}
+		ix.docs = append(ix.docs, d)
+	}
+	return ix
+}
+
+// sourceEntries returns an entry per distinct identifier, shown as the
+// source line it first appears on, and an entry per comment line.
+func sourceEntries(src []byte) []entry {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(string(src), "\n")
+	lineText := func(n int) string {
+		if n < 1 || n > len(lines) {
+			return ""
+		}
+		return strings.TrimSpace(lines[n-1])
+	}
+
+	var entries []entry
+	seen := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok || id.Name == "_" || seen[id.Name] {
+			return true
+		}
+		seen[id.Name] = true
+		line := fset.Position(id.Pos()).Line
+		entries = append(entries, entry{Identifier, line, strings.ToLower(id.Name), lineText(line)})
+		return true
+	})
+
+	for _, g := range f.Comments {
+		for _, c := range g.List {
+			first := fset.Position(c.Pos()).Line
+			for i, text := range strings.Split(c.Text, "\n") {
+				text = strings.TrimPrefix(text, "//")
+				text = strings.TrimPrefix(text, "/*")
+				text = strings.TrimSuffix(text, "*/")
+				text = strings.TrimSpace(text)
+				if text == "" {
+					continue
+				}
+				entries = append(entries, entry{Comment, first + i, strings.ToLower(text), text})
+			}
+		}
+	}
+	return entries
+}
+
+// Hit is a lesson matching a query.
+type Hit struct {
+	Lesson   registry.Lesson
+	Score    int
+	Snippets []Snippet
+}
+
+// Snippet is a line of a lesson that matched, with the byte ranges of
+// Text where the query terms occur.
+type Snippet struct {
+	Field   Field
+	Line    int
+	Text    string
+	Matches [][2]int
+}
+
+// MaxSnippets is how many snippets a hit carries at most.
+const MaxSnippets = 3
+
+// Search returns the lessons in which every term of the query occurs,
+// best match first. Terms are separated by spaces and matched as
+// case-insensitive substrings, so Chinese words need no tokenizer; an
+// English plural also matches its singular ("channels" finds "channel").
+func (ix *Index) Search(query string) []Hit {
+	terms := parseQuery(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var hits []Hit
+	for _, d := range ix.docs {
+		score := 0
+		type match struct {
+			e     entry
+			terms int
+		}
+		var matched []match
+		perEntry := make([]int, len(d.entries))
+		for _, vs := range terms {
+			found := false
+			for i, e := range d.entries {
+				if containsAny(e.key, vs) {
+					found = true
+					score += e.field.weight()
+					perEntry[i]++
+				}
+			}
+			if !found {
+				score = 0
+				break
+			}
+		}
+		if score == 0 {
+			continue
+		}
+		for i, e := range d.entries {
+			if perEntry[i] > 0 && e.field >= Identifier {
+				matched = append(matched, match{e, perEntry[i]})
+			}
+		}
+		// Comments explain, identifiers only point at code: prefer comment
+		// lines, then lines that match more terms, then the earlier line.
+		slices.SortStableFunc(matched, func(a, b match) int {
+			return cmp.Or(
+				cmp.Compare(b.e.field, a.e.field),
+				cmp.Compare(b.terms, a.terms),
+				cmp.Compare(a.e.line, b.e.line),
+			)
+		})
+		h := Hit{Lesson: d.lesson, Score: score}
+		for _, m := range matched {
+			if len(h.Snippets) == MaxSnippets {
+				break
+			}
+			if m.e.text == "" || slices.ContainsFunc(h.Snippets, func(s Snippet) bool { return s.Line == m.e.line }) {
+				continue
+			}
+			h.Snippets = append(h.Snippets, Snippet{
+				Field:   m.e.field,
+				Line:    m.e.line,
+				Text:    m.e.text,
+				Matches: locate(m.e.text, terms),
+			})
+		}
+		hits = append(hits, h)
+	}
+	slices.SortStableFunc(hits, func(a, b Hit) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Lesson.Code, b.Lesson.Code))
+	})
+	return hits
+}
+
+// Locate returns the byte ranges of text where the terms of query occur,
+// for highlighting text that is not a snippet, such as a title.
+func Locate(text, query string) [][2]int {
+	return locate(text, parseQuery(query))
+}
+
+// parseQuery splits a query into terms, each given as its variants.
+func parseQuery(query string) [][]string {
+	var terms [][]string
+	for _, t := range strings.Fields(strings.ToLower(query)) {
+		terms = append(terms, variants(t))
+	}
+	return terms
+}
+
+// variants returns the forms of a lower-cased term that count as a match.
+func variants(t string) []string {
+	vs := []string{t}
+	if len(t) > 3 && strings.HasSuffix(t, "s") {
+		vs = append(vs, t[:len(t)-1])
+		if strings.HasSuffix(t, "es") {
+			vs = append(vs, t[:len(t)-2])
+		}
+	}
+	return vs
+}
+
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// locate returns the sorted, non-overlapping byte ranges of text where any
+// variant of any term occurs.
+func locate(text string, terms [][]string) [][2]int {
+	key := strings.ToLower(text)
+	if len(key) != len(text) {
+		// Lower-casing changed byte offsets; fall back to exact matches.
+		key = text
+	}
+	var ranges [][2]int
+	for _, vs := range terms {
+		for _, v := range vs {
+			for off := 0; ; {
+				i := strings.Index(key[off:], v)
+				if i < 0 {
+					break
+				}
+				ranges = append(ranges, [2]int{off + i, off + i + len(v)})
+				off += i + len(v)
+			}
+		}
+	}
+	slices.SortFunc(ranges, func(a, b [2]int) int { return cmp.Compare(a[0], b[0]) })
+	var merged [][2]int
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], r[1])
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
@@ -0,0 +1,129 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"first-golang/registry"
+)
+
+func TestVariants(t *testing.T) {
+	tests := []struct {
+		term string
+		want []string
+	}{
+		{"channel", []string{"channel"}},
+		{"channels", []string{"channels", "channel"}},
+		{"closes", []string{"closes", "close", "clos"}},
+		{"its", []string{"its"}}, // too short to be a plural
+		{"通道", []string{"通道"}},
+	}
+	for _, tt := range tests {
+		if got := variants(tt.term); !slices.Equal(got, tt.want) {
+			t.Errorf("variants(%q) = %q, want %q", tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		text, query string
+		want        [][2]int
+	}{
+		{"Buffered Channels", "channels", [][2]int{{9, 17}}},
+		{"Buffered Channels", "CHANNEL buffer", [][2]int{{0, 6}, {9, 16}}},
+		{"close the channel, close it", "close", [][2]int{{0, 5}, {19, 24}}},
+		{"Range and Close", "range ran", [][2]int{{0, 5}}}, // overlapping matches merge
+		{"關閉通道", "通道", [][2]int{{6, 12}}},
+		{"Range and Close", "select", nil},
+	}
+	for _, tt := range tests {
+		if got := Locate(tt.text, tt.query); !slices.Equal(got, tt.want) {
+			t.Errorf("Locate(%q, %q) = %v, want %v", tt.text, tt.query, got, tt.want)
+		}
+	}
+}
+
+const channelSrc = `package p
+
+// 用 close 關閉通道，接收端用 range 讀到通道關閉為止
+func fibonacci(n int, c chan int) {
+	close(c)
+}
+`
+
+func testIndex() *Index {
+	return &Index{docs: []doc{
+		{lesson: registry.Lesson{Code: "07-02", Title: "Channels"}, entries: []entry{
+			{field: Title, key: "channels", text: "Channels"},
+		}},
+		{lesson: registry.Lesson{Code: "07-04", Title: "Range and Close"}, entries: append([]entry{
+			{field: Title, key: "range and close", text: "Range and Close"},
+		}, sourceEntries([]byte(channelSrc))...)},
+		{lesson: registry.Lesson{Code: "07-03", Title: "Buffered Channels"}, entries: []entry{
+			{field: Title, key: "buffered channels", text: "Buffered Channels"},
+		}},
+	}}
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string // codes, best first
+	}{
+		{"channels", []string{"07-02", "07-03"}},
+		{"Channel", []string{"07-02", "07-03"}},
+		{"close", []string{"07-04"}},
+		{"buffered channel", []string{"07-03"}},
+		{"通道", []string{"07-04"}},
+		{"range close", []string{"07-04"}},
+		{"select", nil},
+		{"  ", nil},
+	}
+	ix := testIndex()
+	for _, tt := range tests {
+		var got []string
+		for _, h := range ix.Search(tt.query) {
+			got = append(got, h.Lesson.Code)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Search(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchRanking(t *testing.T) {
+	// A title hit outweighs any number of comment lines.
+	ix := &Index{docs: []doc{
+		{lesson: registry.Lesson{Code: "01-01"}, entries: []entry{
+			{field: Comment, line: 1, key: "a mutex guards the map"},
+			{field: Comment, line: 2, key: "lock the mutex"},
+		}},
+		{lesson: registry.Lesson{Code: "07-09", Title: "sync.Mutex"}, entries: []entry{
+			{field: Title, key: "sync.mutex"},
+		}},
+	}}
+	hits := ix.Search("mutex")
+	if len(hits) != 2 || hits[0].Lesson.Code != "07-09" || hits[0].Score <= hits[1].Score {
+		t.Errorf("Search(mutex) ranks %v", hits)
+	}
+}
+
+func TestSnippets(t *testing.T) {
+	hits := testIndex().Search("close")
+	if len(hits) != 1 {
+		t.Fatalf("Search(close) = %v, want one hit", hits)
+	}
+	snips := hits[0].Snippets
+	if len(snips) == 0 || snips[0].Field != Comment || snips[0].Line != 3 {
+		t.Fatalf("snippets = %+v, want the comment on line 3 first", snips)
+	}
+	for _, s := range snips {
+		for _, m := range s.Matches {
+			if got := strings.ToLower(s.Text[m[0]:m[1]]); got != "close" {
+				t.Errorf("snippet %q highlights %q", s.Text, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Package solutions ships the staged hints and the reference solution of
+// every exercise inside the binary, so they stay hidden until asked for.
+//
+// hints/<code>.txt holds the hints of an exercise, easiest first,
+// separated by lines of "---". ref/<code>.go.txt is the reference
+// solution: only the declarations the exercise asks for, such as Sqrt or
+// WordCount, with their doc comments, as Extract prints them. The rest of
+// the lesson file is not repeated there, so it can change freely.
+package solutions
+
+import (
+	"embed"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+//go:embed hints/*.txt ref/*.go.txt
+var files embed.FS
+
+// Hints returns the hints of an exercise, easiest first, or nil if it
+// has none.
+func Hints(code string) []string {
+	data, err := files.ReadFile("hints/" + code + ".txt")
+	if err != nil {
+		return nil
+	}
+	var hints []string
+	for _, h := range strings.Split(string(data), "\n---\n") {
+		if h = strings.TrimSpace(h); h != "" {
+			hints = append(hints, h)
+		}
+	}
+	return hints
+}
+
+// Reference returns the reference solution of an exercise.
+func Reference(code string) (string, bool) {
+	data, err := files.ReadFile("ref/" + code + ".go.txt")
+	return string(data), err == nil
+}
+
+// Extract returns the top-level declarations of the lesson file src that
+// the reference solution ref also declares, with their doc comments, in
+// the order of ref and separated by blank lines, so that the result can
+// be compared with ref line by line. A declaration missing from src is
+// left out. Methods are matched by receiver type and name.
+func Extract(src, ref string) (string, error) {
+	fset := token.NewFileSet()
+	rf, err := parser.ParseFile(fset, "reference", "package p\n"+ref, parser.SkipObjectResolution)
+	if err != nil {
+		return "", err
+	}
+	var order []string
+	for _, d := range rf.Decls {
+		order = append(order, declNames(d)...)
+	}
+
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return "", err
+	}
+	text := make(map[string]string)
+	for _, d := range f.Decls {
+		start := d.Pos()
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		case *ast.GenDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		}
+		decl := src[fset.Position(start).Offset:fset.Position(d.End()).Offset]
+		for _, name := range declNames(d) {
+			text[name] = decl
+		}
+	}
+
+	var decls []string
+	done := make(map[string]bool)
+	for _, name := range order {
+		if d, ok := text[name]; ok && !done[d] {
+			done[d] = true
+			decls = append(decls, d)
+		}
+	}
+	if len(decls) == 0 {
+		return "", nil
+	}
+	return strings.Join(decls, "\n\n") + "\n", nil
+}
+
+// declNames returns the names a top-level declaration declares; a method
+// is named "Type.Method".
+func declNames(d ast.Decl) []string {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		if d.Recv == nil || len(d.Recv.List) == 0 {
+			return []string{d.Name.Name}
+		}
+		return []string{recvName(d.Recv.List[0].Type) + "." + d.Name.Name}
+	case *ast.GenDecl:
+		var names []string
+		for _, spec := range d.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, spec.Name.Name)
+			case *ast.ValueSpec:
+				for _, n := range spec.Names {
+					names = append(names, n.Name)
+				}
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// recvName returns the name of a receiver's type, without pointer or type
+// parameters.
+func recvName(t ast.Expr) string {
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+		case *ast.IndexExpr:
+			t = x.X
+		case *ast.IndexListExpr:
+			t = x.X
+		case *ast.ParenExpr:
+			t = x.X
+		case *ast.Ident:
+			return x.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// Statement returns the explanatory comment blocks at the top of a lesson
+// file: the comments after the imports, up to and including the doc
+// comment of the first declaration. The tour URL header is left out. It
+// returns "" if there are none or src does not parse.
+func Statement(src string) string {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return ""
+	}
+	start, end := f.Name.End(), token.Pos(f.FileEnd)
+	for _, d := range f.Decls {
+		if g, ok := d.(*ast.GenDecl); ok && g.Tok == token.IMPORT {
+			start = g.End()
+			continue
+		}
+		end = d.Pos()
+		break
+	}
+
+	var blocks []string
+	for _, g := range f.Comments {
+		if g.Pos() < start || g.End() > end {
+			continue
+		}
+		blocks = append(blocks, strings.TrimRight(g.Text(), "\n"))
+	}
+	return strings.Join(blocks, "\n\n")
+}
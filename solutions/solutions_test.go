@@ -0,0 +1,69 @@
+package solutions
+
+import "testing"
+
+func TestExtract(t *testing.T) {
+	const src = `package p
+
+import "strings"
+
+// 題目說明，不屬於任何宣告
+
+type T struct{}
+
+// Len 回傳長度
+func (t *T) Len() int { return 0 }
+
+func helper() {}
+
+// Words 切開字串
+func Words(s string) []string {
+	return strings.Fields(s)
+}
+`
+	const ref = `// Words 切開字串
+func Words(s string) []string {
+	return strings.Fields(s)
+}
+
+// Len 回傳長度
+func (t T) Len() int { return 1 }
+
+func Missing() {}
+`
+	const want = `// Words 切開字串
+func Words(s string) []string {
+	return strings.Fields(s)
+}
+
+// Len 回傳長度
+func (t *T) Len() int { return 0 }
+`
+	got, err := Extract(src, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("Extract =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestReferencesParse(t *testing.T) {
+	refs, err := files.ReadDir("ref")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range refs {
+		ref, _ := files.ReadFile("ref/" + f.Name())
+		// A reference extracted from itself must come out unchanged, or
+		// 'solution' would show a diff for a file that matches it.
+		got, err := Extract("package p\n\n"+string(ref), string(ref))
+		if err != nil {
+			t.Errorf("%s: %v", f.Name(), err)
+			continue
+		}
+		if got != string(ref) {
+			t.Errorf("%s is not in the form Extract prints:\n%s", f.Name(), got)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+//go:build ignore
+
+// Fetch refreshes the offline snapshot of the tour in content/ from the
+// tour's sources in the golang.org/x/website repository:
+//
+//	go generate ./tour
+//
+// Only the sections already in content/ are fetched. The tour text is
+// Copyright 2009 The Go Authors and distributed under the BSD license
+// kept in content/LICENSE; content/SOURCE is rewritten with the upstream
+// commit the copy was taken from.
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	repo     = "golang/website"
+	tourPath = "_content/tour"
+)
+
+const source = `The *.article files in this directory are an unmodified copy of the
+A Tour of Go text, Copyright 2009 The Go Authors, distributed under the
+BSD license in LICENSE.
+
+Upstream:  https://github.com/%s/tree/%s/%s
+Revision:  %s (fetched %s)
+
+'go generate ./tour' refreshes the copy and rewrites this file with the
+upstream commit it was taken from.
+`
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("fetch: ")
+	files, err := filepath.Glob(filepath.Join("content", "*.article"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(files) == 0 {
+		log.Fatal("no content/*.article to refresh; run from the tour directory")
+	}
+	// Pin master to one commit, so that every file comes from the same
+	// revision and SOURCE can name it.
+	rev, err := get("https://api.github.com/repos/" + repo + "/commits/master")
+	if err != nil {
+		log.Fatal(err)
+	}
+	revision := strings.TrimSpace(string(rev))
+	for _, file := range files {
+		name := filepath.Base(file)
+		data, err := get("https://raw.githubusercontent.com/" + repo + "/" + revision + "/" + tourPath + "/" + name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(file, data, 0o644); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s: %d pages\n", file, strings.Count("\n"+string(data), "\n* "))
+	}
+	text := fmt.Sprintf(source, repo, revision, tourPath, revision, time.Now().Format(time.DateOnly))
+	if err := os.WriteFile(filepath.Join("content", "SOURCE"), []byte(text), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func get(url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Makes the GitHub API answer with the bare commit hash.
+	req.Header.Set("Accept", "application/vnd.github.sha")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,197 @@
+// Package tour knows the pages of the Go Tour the lessons follow: it
+// parses the "// https://go.dev/tour/..." header every lesson file starts
+// with, and carries an offline snapshot of the text of every tour page, so
+// a lesson's description can be read next to its code without a network.
+//
+// The snapshot is content/<section>.article, in the format of the tour's
+// own sources. 'go generate' refreshes it from upstream.
+package tour
+
+//go:generate go run fetch.go
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// BaseURL is where the tour's pages live.
+const BaseURL = "https://go.dev/tour/"
+
+// Page identifies a page of the tour, such as moretypes/18.
+type Page struct {
+	Section string // "moretypes"
+	Number  int    // counting from 1
+}
+
+// URL returns the page's address on go.dev.
+func (p Page) URL() string {
+	return fmt.Sprintf("%s%s/%d", BaseURL, p.Section, p.Number)
+}
+
+func (p Page) String() string {
+	return fmt.Sprintf("%s/%d", p.Section, p.Number)
+}
+
+// ParseURL returns the page a tour URL points to.
+func ParseURL(url string) (Page, error) {
+	rest, ok := strings.CutPrefix(url, BaseURL)
+	section, num, ok2 := strings.Cut(rest, "/")
+	n, err := strconv.Atoi(num)
+	if !ok || !ok2 || section == "" || err != nil || n < 1 {
+		return Page{}, fmt.Errorf("%q is not a tour page URL (want %s<section>/<page>)", url, BaseURL)
+	}
+	return Page{Section: section, Number: n}, nil
+}
+
+var header = regexp.MustCompile(`^// (https://go\.dev/tour/\S+)$`)
+
+// Header returns the tour URL in the first line of a lesson file, and
+// false if the file does not start with one.
+func Header(src []byte) (string, bool) {
+	first, _, _ := strings.Cut(string(src), "\n")
+	m := header.FindStringSubmatch(strings.TrimSpace(first))
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// Slide is the text of one tour page, as plain text: links are reduced to
+// their text, emphasis marks are dropped, and code is kept with its tab
+// indentation. Inline code stays between backquotes.
+type Slide struct {
+	Page  Page
+	Title string
+	Text  string
+}
+
+//go:embed content/*.article
+var content embed.FS
+
+var slides = sync.OnceValue(func() map[Page]Slide {
+	m := make(map[Page]Slide)
+	files, _ := content.ReadDir("content")
+	for _, f := range files {
+		data, err := content.ReadFile("content/" + f.Name())
+		if err != nil {
+			continue
+		}
+		section := strings.TrimSuffix(f.Name(), path.Ext(f.Name()))
+		for _, s := range parseArticle(section, string(data)) {
+			m[s.Page] = s
+		}
+	}
+	return m
+})
+
+// Lookup returns the offline text of a page, and false if the snapshot
+// does not have it.
+func Lookup(p Page) (Slide, bool) {
+	s, ok := slides()[p]
+	return s, ok
+}
+
+// LookupURL is Lookup for a page given by its URL.
+func LookupURL(url string) (Slide, bool) {
+	p, err := ParseURL(url)
+	if err != nil {
+		return Slide{}, false
+	}
+	return Lookup(p)
+}
+
+// parseArticle splits an article into its pages. The title block before
+// the first "* Title" line is not a page. Directives such as .play and
+// .image, and # comment lines, are dropped.
+func parseArticle(section, src string) []Slide {
+	var pages []Slide
+	var body []string
+	flush := func() {
+		if n := len(pages); n > 0 {
+			pages[n-1].Text = joinParagraphs(body)
+		}
+		body = nil
+	}
+	for _, line := range strings.Split(src, "\n") {
+		if title, ok := strings.CutPrefix(line, "* "); ok {
+			flush()
+			pages = append(pages, Slide{
+				Page:  Page{Section: section, Number: len(pages) + 1},
+				Title: strings.TrimSpace(title),
+			})
+			continue
+		}
+		if len(pages) == 0 || strings.HasPrefix(line, ".") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "\t") {
+			body = append(body, line) // code is kept as is
+			continue
+		}
+		body = append(body, inline(line))
+	}
+	flush()
+	return pages
+}
+
+// joinParagraphs joins lines, collapsing runs of blank lines into one and
+// trimming them at both ends.
+func joinParagraphs(lines []string) string {
+	var out []string
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" && (len(out) == 0 || out[len(out)-1] == "") {
+			continue
+		}
+		out = append(out, line)
+	}
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n")
+}
+
+var link = regexp.MustCompile(`\[\[([^\]]*)\](?:\[([^\]]*)\])?\]`)
+
+// inline reduces the markup of a line of text to plain text: [[url][text]]
+// becomes text and [[url]] the url; *bold* and _italic_ lose their marks
+// and `code` keeps its backquotes. Inside a marked word the mark stands
+// for a space, as in _zero_value_ or `package`rand`.
+func inline(line string) string {
+	line = link.ReplaceAllStringFunc(line, func(s string) string {
+		m := link.FindStringSubmatch(s)
+		if m[2] != "" {
+			return m[2]
+		}
+		return m[1]
+	})
+	words := strings.Split(line, " ")
+	for i, w := range words {
+		words[i] = unmark(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// unmark handles one space-separated word; punctuation around the marked
+// part, as in "(`x`)," is left alone.
+func unmark(w string) string {
+	start := strings.IndexFunc(w, func(r rune) bool { return !strings.ContainsRune(`("'`, r) })
+	end := strings.LastIndexFunc(w, func(r rune) bool { return !strings.ContainsRune(`.,;:!?)"'`, r) }) + 1
+	if start < 0 || end-start < 2 {
+		return w
+	}
+	mark := w[start]
+	if w[end-1] != mark || !strings.ContainsRune("`*_", rune(mark)) {
+		return w
+	}
+	inner := strings.ReplaceAll(w[start+1:end-1], string(mark), " ")
+	if mark == '`' {
+		inner = "`" + inner + "`"
+	}
+	return w[:start] + inner + w[end:]
+}
@@ -0,0 +1,131 @@
+// Package tourio ports the exercise helpers of golang.org/x/tour (pic.Show,
+// pic.ShowImage, wc.Test and reader.Validate) so they write to a given
+// io.Writer instead of os.Stdout. Output is byte-for-byte the same as the
+// originals. The Check variants return the verdict as an error instead,
+// for validating exercises.
+package tourio
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+)
+
+// ShowPic is pic.Show: it renders f(256, 256) as a bluish image and
+// writes it to w.
+func ShowPic(w io.Writer, f func(dx, dy int) [][]uint8) {
+	const (
+		dx = 256
+		dy = 256
+	)
+	data := f(dx, dy)
+	m := image.NewNRGBA(image.Rect(0, 0, dx, dy))
+	for y := 0; y < dy; y++ {
+		for x := 0; x < dx; x++ {
+			v := data[y][x]
+			i := y*m.Stride + x*4
+			m.Pix[i] = v
+			m.Pix[i+1] = v
+			m.Pix[i+2] = 255
+			m.Pix[i+3] = 255
+		}
+	}
+	ShowImage(w, m)
+}
+
+// ShowImage is pic.ShowImage: it writes m to w as a base64 PNG prefixed
+// with "IMAGE:", which the tour playground turns into a picture.
+func ShowImage(w io.Writer, m image.Image) {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+	io.WriteString(bw, "IMAGE:")
+	b64 := base64.NewEncoder(base64.StdEncoding, bw)
+	err := (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(b64, m)
+	if err != nil {
+		panic(err)
+	}
+	b64.Close()
+	io.WriteString(bw, "\n")
+}
+
+// WordCountCases are the inputs and expected counts used by wc.Test.
+var WordCountCases = []struct {
+	In   string
+	Want map[string]int
+}{
+	{"I am learning Go!", map[string]int{
+		"I": 1, "am": 1, "learning": 1, "Go!": 1,
+	}},
+	{"The quick brown fox jumped over the lazy dog.", map[string]int{
+		"The": 1, "quick": 1, "brown": 1, "fox": 1, "jumped": 1,
+		"over": 1, "the": 1, "lazy": 1, "dog.": 1,
+	}},
+	{"I ate a donut. Then I ate another donut.", map[string]int{
+		"I": 2, "ate": 2, "a": 1, "donut.": 2, "Then": 1, "another": 1,
+	}},
+	{"A man a plan a canal panama.", map[string]int{
+		"A": 1, "man": 1, "a": 2, "plan": 1, "canal": 1, "panama.": 1,
+	}},
+}
+
+// TestWordCount is wc.Test: it runs f on WordCountCases and writes PASS
+// or FAIL for each, stopping at the first failure.
+func TestWordCount(w io.Writer, f func(string) map[string]int) {
+	for _, c := range WordCountCases {
+		got := f(c.In)
+		if !sameCounts(got, c.Want) {
+			fmt.Fprintf(w, "FAIL\n f(%q) =\n  %#v\n want:\n  %#v",
+				c.In, got, c.Want)
+			break
+		}
+		fmt.Fprintf(w, "PASS\n f(%q) = \n  %#v\n", c.In, got)
+	}
+}
+
+func sameCounts(got, want map[string]int) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for k := range want {
+		if want[k] != got[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateReader is reader.Validate: it reads 1MB from r and checks that
+// every byte is 'A'. Problems are written to w as well, not to os.Stderr.
+func ValidateReader(w io.Writer, r io.Reader) {
+	if err := CheckReader(r); err != nil {
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintln(w, "OK!")
+}
+
+// CheckReader reads 1MB from r like ValidateReader and returns an error
+// for the first problem found.
+func CheckReader(r io.Reader) error {
+	b := make([]byte, 1024, 2048)
+	i, o := 0, 0
+	for ; i < 1<<20 && o < 1<<20; i++ { // test 1mb
+		n, err := r.Read(b)
+		for i, v := range b[:n] {
+			if v != 'A' {
+				return fmt.Errorf("got byte %x at offset %v, want 'A'", v, o+i)
+			}
+		}
+		o += n
+		if err != nil {
+			return fmt.Errorf("read error: %v", err)
+		}
+	}
+	if o == 0 {
+		return fmt.Errorf("read zero bytes after %d Read calls", i)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package web
+
+import (
+	"html/template"
+	"strings"
+)
+
+// layout is shared by every page; pages define "title" and "body".
+const layout = `<!DOCTYPE html>
+<html lang="zh-Hant">
+<head>
+<meta charset="utf-8">
+<title>{{template "title" .}}</title>
+<style>
+body { font-family: sans-serif; margin: 0 auto; max-width: 60em; padding: 1em 2em; color: #222; }
+a { color: #007d9c; text-decoration: none; }
+a:hover { text-decoration: underline; }
+h2 { border-bottom: 1px solid #ddd; padding-bottom: .2em; }
+ul.lessons { list-style: none; padding-left: 0; }
+ul.lessons li { padding: .15em 0; }
+code.lesson { color: #888; margin-right: .6em; }
+nav { display: flex; justify-content: space-between; margin: 1em 0; }
+pre { background: #f6f8fa; border: 1px solid #ddd; padding: .8em; overflow-x: auto; line-height: 1.35; }
+pre.output { background: #1e1e1e; color: #eee; min-height: 2em; white-space: pre-wrap; }
+button { font-size: 1em; padding: .3em 1.2em; cursor: pointer; }
+.ln { color: #aaa; user-select: none; display: inline-block; width: 3em; }
+.tour { white-space: pre-wrap; border-left: 3px solid #007d9c; padding-left: 1em; color: #444; }
+</style>
+</head>
+<body>
+{{template "body" .}}
+</body>
+</html>
+`
+
+const indexBody = `
+{{define "title"}}Go Tour 自學筆記{{end}}
+{{define "body"}}
+<h1>Go Tour 自學筆記</h1>
+{{range .}}
+<h2>{{.Name}}</h2>
+<ul class="lessons">
+{{range .Lessons}}<li><code class="lesson">{{.Code}}</code><a href="/lesson/{{.Code}}">{{.Title}}</a>{{if .IsExercise}} ✎{{end}}</li>
+{{end}}</ul>
+{{end}}
+{{end}}
+`
+
+const lessonBody = `
+{{define "title"}}{{.Lesson.Code}} {{.Lesson.Title}}{{end}}
+{{define "body"}}
+<nav>
+<span>{{with .Prev}}<a href="/lesson/{{.Code}}">← {{.Code}} {{.Title}}</a>{{end}}</span>
+<a href="/">目錄</a>
+<span>{{with .Next}}<a href="/lesson/{{.Code}}">{{.Code}} {{.Title}} →</a>{{end}}</span>
+</nav>
+<h1>{{.Lesson.Code}} {{.Lesson.Title}}</h1>
+<p>{{.Lesson.File}}{{with .Lesson.TourURL}} · <a href="{{.}}">{{.}}</a>{{end}}</p>
+{{with .Tour}}<div class="tour" lang="en">{{.Text}}</div>{{end}}
+
+<p><button id="run">Run</button></p>
+<pre class="output" id="output"></pre>
+
+{{if .SourceErr}}<p>Source unavailable: {{.SourceErr}}</p>
+{{else}}<pre>{{range $i, $line := lines .Source}}<span class="ln">{{inc $i}}</span>{{$line}}
+{{end}}</pre>{{end}}
+
+<script>
+const button = document.getElementById("run");
+const output = document.getElementById("output");
+button.addEventListener("click", async () => {
+	button.disabled = true;
+	output.textContent = "";
+	try {
+		const resp = await fetch("/run/{{.Lesson.Code}}", {method: "POST"});
+		const reader = resp.body.getReader();
+		const decoder = new TextDecoder();
+		for (;;) {
+			const {done, value} = await reader.read();
+			if (done) break;
+			output.textContent += decoder.decode(value, {stream: true});
+		}
+	} catch (err) {
+		output.textContent += "\n" + err;
+	}
+	button.disabled = false;
+});
+</script>
+{{end}}
+`
+
+var funcs = template.FuncMap{
+	"lines": splitLines,
+	"inc":   func(i int) int { return i + 1 },
+}
+
+var (
+	base       = template.Must(template.New("layout").Funcs(funcs).Parse(layout))
+	indexPage  = template.Must(template.Must(base.Clone()).Parse(indexBody))
+	lessonPage = template.Must(template.Must(base.Clone()).Parse(lessonBody))
+)
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,140 @@
+// Package web serves the lessons over HTTP the way the Go Tour does: an
+// index by chapter, a page per lesson with its source, and a Run button
+// that executes the lesson on the server and streams its output back.
+// Everything is served from the binary, so it works offline.
+package web
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"os"
+	"path"
+
+	"first-golang/registry"
+	"first-golang/runner"
+	"first-golang/tour"
+)
+
+// Server serves a fixed set of lessons.
+type Server struct {
+	lessons []registry.Lesson
+	opts    runner.Options
+	mux     *http.ServeMux
+}
+
+// New returns a server for the lessons, in the order given, running each
+// under opts.
+func New(lessons []registry.Lesson, opts runner.Options) *Server {
+	s := &Server{lessons: lessons, opts: opts, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /{$}", s.index)
+	s.mux.HandleFunc("GET /lesson/{code}", s.lesson)
+	// Running a lesson executes code on this machine, so only the pages
+	// served here may ask for it: a POST from another site's page, told
+	// apart by its Sec-Fetch-Site or Origin header, is refused with 403.
+	s.mux.Handle("POST /run/{code}", http.NewCrossOriginProtection().Handler(http.HandlerFunc(s.run)))
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) lookup(code string) (int, bool) {
+	for i, l := range s.lessons {
+		if l.Code == code {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+type chapter struct {
+	Name    string // source directory, e.g. "04-more-types"
+	Lessons []registry.Lesson
+}
+
+func (s *Server) index(w http.ResponseWriter, r *http.Request) {
+	var chapters []chapter
+	for _, l := range s.lessons {
+		if n := len(chapters); n == 0 || chapters[n-1].Lessons[0].Chapter() != l.Chapter() {
+			chapters = append(chapters, chapter{Name: path.Dir(l.File)})
+		}
+		c := &chapters[len(chapters)-1]
+		c.Lessons = append(c.Lessons, l)
+	}
+	render(w, indexPage, chapters)
+}
+
+type lessonData struct {
+	Lesson     registry.Lesson
+	Prev, Next *registry.Lesson
+	Source     string
+	SourceErr  error
+	Tour       *tour.Slide // nil if the offline tour lacks the page
+}
+
+func (s *Server) lesson(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.lookup(r.PathValue("code"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	d := lessonData{Lesson: s.lessons[i]}
+	if i > 0 {
+		d.Prev = &s.lessons[i-1]
+	}
+	if i+1 < len(s.lessons) {
+		d.Next = &s.lessons[i+1]
+	}
+	src, err := os.ReadFile(d.Lesson.Path())
+	d.Source, d.SourceErr = string(src), err
+	if slide, ok := tour.LookupURL(d.Lesson.TourURL); ok {
+		d.Tour = &slide
+	}
+	render(w, lessonPage, d)
+}
+
+// run executes a lesson and streams what it prints as plain text, flushing
+// after every write so the page shows output as it is produced. The
+// status footer and, for an exercise, the check verdict follow.
+func (s *Server) run(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.lookup(r.PathValue("code"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	l := s.lessons[i]
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	out := flushWriter{w, http.NewResponseController(w)}
+
+	res := runner.Run(l, out, s.opts)
+	fmt.Fprintln(out)
+	runner.Report(out, res)
+	if l.IsExercise() && !res.Failed() {
+		runner.ReportCheck(out, l, runner.Check(l, s.opts))
+	}
+}
+
+// flushWriter sends every write to the client right away.
+type flushWriter struct {
+	w  io.Writer
+	rc *http.ResponseController
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err == nil {
+		f.rc.Flush()
+	}
+	return n, err
+}
+
+func render(w http.ResponseWriter, t *template.Template, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := t.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,45 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"first-golang/registry"
+	"first-golang/runner"
+)
+
+func TestRunCrossOrigin(t *testing.T) {
+	lesson := registry.Lesson{Code: "01-01", Title: "Hello", Run: func(w io.Writer) { io.WriteString(w, "hello\n") }}
+	s := New([]registry.Lesson{lesson}, runner.Options{})
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{"no browser headers", nil, http.StatusOK},
+		{"same origin", map[string]string{"Sec-Fetch-Site": "same-origin", "Origin": "http://localhost:3999"}, http.StatusOK},
+		{"same host origin", map[string]string{"Origin": "http://localhost:3999"}, http.StatusOK},
+		{"cross site", map[string]string{"Sec-Fetch-Site": "cross-site", "Origin": "https://evil.example"}, http.StatusForbidden},
+		{"other origin", map[string]string{"Origin": "https://evil.example"}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "http://localhost:3999/run/01-01", nil)
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status %d, want %d", w.Code, tt.want)
+			}
+			if ran := strings.Contains(w.Body.String(), "hello"); ran != (tt.want == http.StatusOK) {
+				t.Errorf("lesson ran: %v, body %q", ran, w.Body.String())
+			}
+		})
+	}
+}